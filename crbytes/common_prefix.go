@@ -34,3 +34,37 @@ func commonPrefixGeneric(a, b []byte) int {
 	}
 	return shared
 }
+
+// PrefixLengths sets out[i] to the length of the common prefix between keys[i-1]
+// and keys[i] (with out[0] = 0) and returns out. The out slice is reused if it
+// has enough capacity, otherwise a new slice is allocated.
+//
+// This is useful for prefix-compressing a sorted sequence of keys.
+func PrefixLengths(keys [][]byte, out []int) []int {
+	if cap(out) < len(keys) {
+		out = make([]int, len(keys))
+	}
+	out = out[:len(keys)]
+	if len(keys) == 0 {
+		return out
+	}
+	out[0] = 0
+	prev := keys[0]
+	for i, k := range keys[1:] {
+		out[i+1] = CommonPrefix(prev, k)
+		prev = k
+	}
+	return out
+}
+
+// FirstDiff returns the index of the first byte that differs between a and b,
+// along with the differing bytes. If one slice is a prefix of the other (or
+// they are equal), differ is false and index is the length of the shorter
+// slice.
+func FirstDiff(a, b []byte) (index int, aByte, bByte byte, differ bool) {
+	index = CommonPrefix(a, b)
+	if index == len(a) || index == len(b) {
+		return index, 0, 0, false
+	}
+	return index, a[index], b[index], true
+}
@@ -103,3 +103,49 @@ func lexicographicSet(minLength, maxLength int) [][]byte {
 	slices.SortFunc(result, bytes.Compare)
 	return result
 }
+
+// BenchmarkPrefixLengths compares PrefixLengths against calling CommonPrefix in
+// a loop. The two are equivalent in performance; CommonPrefix dominates the
+// cost.
+//
+// Sample benchmark results on linux/amd64, Intel(R) Xeon(R) Processor:
+//
+//	PrefixLengths/small/PrefixLengths    90.6ns
+//	PrefixLengths/small/loop             84.9ns
+//	PrefixLengths/medium/PrefixLengths    135ns
+//	PrefixLengths/medium/loop             135ns
+//	PrefixLengths/large/PrefixLengths    2.68µs
+//	PrefixLengths/large/loop             2.71µs
+func BenchmarkPrefixLengths(b *testing.B) {
+	for _, tc := range []struct {
+		name  string
+		input [][]byte
+	}{
+		{name: "small", input: lexicographicSet(4, 16)},
+		{name: "medium", input: lexicographicSet(10, 100)},
+		{name: "large", input: lexicographicSet(1000, 10000)},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			// Use blocks of 16 keys, typical of a restart interval.
+			const blockSize = 16
+			out := make([]int, blockSize)
+			b.Run("PrefixLengths", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					j := (i * blockSize) % (len(tc.input) - blockSize)
+					out = PrefixLengths(tc.input[j:j+blockSize], out)
+				}
+			})
+			b.Run("loop", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					j := (i * blockSize) % (len(tc.input) - blockSize)
+					keys := tc.input[j : j+blockSize]
+					out = out[:len(keys)]
+					out[0] = 0
+					for k := 1; k < len(keys); k++ {
+						out[k] = CommonPrefix(keys[k-1], keys[k])
+					}
+				}
+			})
+		})
+	}
+}
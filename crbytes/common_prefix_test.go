@@ -84,3 +84,50 @@ func genBytes(length int, alphabet int) []byte {
 	}
 	return a
 }
+
+func TestPrefixLengths(t *testing.T) {
+	if res := PrefixLengths(nil, nil); len(res) != 0 {
+		t.Errorf("expected empty result, got %v", res)
+	}
+	for n := 0; n < 100; n++ {
+		keys := lexicographicSet(rand.Intn(10), 20)[:rand.Intn(50)+1]
+		out := make([]int, rand.Intn(60))
+		res := PrefixLengths(keys, out)
+		if len(res) != len(keys) {
+			t.Fatalf("expected length %d, got %d", len(keys), len(res))
+		}
+		for i := range keys {
+			expected := 0
+			if i > 0 {
+				expected = commonPrefixNaive(keys[i-1], keys[i])
+			}
+			if res[i] != expected {
+				t.Errorf("%q %q expected=%d result=%d\n", keys[max(i-1, 0)], keys[i], expected, res[i])
+			}
+		}
+	}
+}
+
+func TestFirstDiff(t *testing.T) {
+	for _, tc := range []struct {
+		a, b   string
+		index  int
+		differ bool
+	}{
+		{"", "", 0, false},
+		{"abc", "abc", 3, false},
+		{"ab", "abc", 2, false},
+		{"abc", "ab", 2, false},
+		{"abc", "abd", 2, true},
+		{"xbc", "abc", 0, true},
+		{"0123456789abcdefghij", "0123456789abcdefghiJ", 19, true},
+	} {
+		index, aByte, bByte, differ := FirstDiff([]byte(tc.a), []byte(tc.b))
+		if index != tc.index || differ != tc.differ {
+			t.Errorf("%q %q: expected %d %t, got %d %t", tc.a, tc.b, tc.index, tc.differ, index, differ)
+		}
+		if differ && (aByte != tc.a[index] || bByte != tc.b[index]) {
+			t.Errorf("%q %q: unexpected bytes %q %q", tc.a, tc.b, aByte, bByte)
+		}
+	}
+}
@@ -15,7 +15,10 @@
 package crbytes
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"fmt"
+	"slices"
 	"unsafe"
 )
 
@@ -44,3 +47,45 @@ func CopyAligned(s []byte) []byte {
 	copy(dst, s)
 	return dst
 }
+
+// AppendMany appends all the given parts to dst. It grows dst at most once,
+// which avoids repeated reallocations when dst does not have enough capacity.
+func AppendMany(dst []byte, parts ...[]byte) []byte {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	dst = slices.Grow(dst, n)
+	for _, p := range parts {
+		dst = append(dst, p...)
+	}
+	return dst
+}
+
+// ConstantTimeEqual returns true if the two slices have the same contents. The
+// time taken depends on the lengths of the slices but not on their contents,
+// making it suitable for comparing secrets (such as tokens or MACs).
+//
+// Slices of different lengths are never equal; in that case the function
+// returns immediately, so the lengths themselves are not kept secret.
+func ConstantTimeEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// Min returns whichever of a and b sorts first (according to bytes.Compare),
+// or a if they are equal. The result aliases one of the inputs.
+func Min(a, b []byte) []byte {
+	if bytes.Compare(b, a) < 0 {
+		return b
+	}
+	return a
+}
+
+// Max returns whichever of a and b sorts last (according to bytes.Compare), or
+// a if they are equal. The result aliases one of the inputs.
+func Max(a, b []byte) []byte {
+	if bytes.Compare(b, a) > 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crbytes
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestAppendMany(t *testing.T) {
+	for _, tc := range []struct {
+		dst   []byte
+		parts [][]byte
+	}{
+		{dst: nil, parts: nil},
+		{dst: []byte("a"), parts: nil},
+		{dst: nil, parts: [][]byte{[]byte("a"), nil, []byte("bc")}},
+		{dst: make([]byte, 2, 100), parts: [][]byte{[]byte("a"), []byte("bc"), []byte("def")}},
+	} {
+		expected := append([]byte(nil), tc.dst...)
+		for _, p := range tc.parts {
+			expected = append(expected, p...)
+		}
+		if res := AppendMany(tc.dst, tc.parts...); !bytes.Equal(res, expected) {
+			t.Errorf("expected %q, got %q", expected, res)
+		}
+	}
+}
+
+func TestConstantTimeEqual(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+	}{
+		{"", ""},
+		{"a", ""},
+		{"abc", "abc"},
+		{"abc", "abd"},
+		{"abc", "ab"},
+		{"xbc", "abc"},
+	} {
+		expected := tc.a == tc.b
+		if res := ConstantTimeEqual([]byte(tc.a), []byte(tc.b)); res != expected {
+			t.Errorf("%q %q expected=%t result=%t", tc.a, tc.b, expected, res)
+		}
+	}
+	// Nil and empty slices are equal.
+	if !ConstantTimeEqual(nil, []byte{}) {
+		t.Errorf("expected nil and empty slice to be equal")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		min, max string
+	}{
+		{"", "", "", ""},
+		{"", "a", "", "a"},
+		{"a", "", "", "a"},
+		{"ab", "a", "a", "ab"},
+		{"abc", "abd", "abc", "abd"},
+		{"b", "abc", "abc", "b"},
+	} {
+		a, b := []byte(tc.a), []byte(tc.b)
+		if res := Min(a, b); string(res) != tc.min {
+			t.Errorf("Min(%q, %q) = %q, expected %q", tc.a, tc.b, res, tc.min)
+		}
+		if res := Max(a, b); string(res) != tc.max {
+			t.Errorf("Max(%q, %q) = %q, expected %q", tc.a, tc.b, res, tc.max)
+		}
+	}
+	// The result aliases an input; for equal inputs, it is the first one.
+	a, b := []byte("x"), []byte("x")
+	if &Min(a, b)[0] != &a[0] || &Max(a, b)[0] != &a[0] {
+		t.Errorf("expected result to alias the first argument")
+	}
+}
+
+// Sample benchmark results on linux/amd64, Intel(R) Xeon(R) Processor:
+//
+//	AppendMany/parts=2/append              68.3ns   2 allocs/op
+//	AppendMany/parts=2/AppendMany          42.7ns   1 allocs/op
+//	AppendMany/parts=2/AppendMany/reuse    11.0ns   0 allocs/op
+//	AppendMany/parts=4/append               185ns   4 allocs/op
+//	AppendMany/parts=4/AppendMany          71.7ns   1 allocs/op
+//	AppendMany/parts=4/AppendMany/reuse    20.0ns   0 allocs/op
+//	AppendMany/parts=16/append             1.49µs   9 allocs/op
+//	AppendMany/parts=16/AppendMany          475ns   1 allocs/op
+//	AppendMany/parts=16/AppendMany/reuse   85.3ns   0 allocs/op
+func BenchmarkAppendMany(b *testing.B) {
+	for _, numParts := range []int{2, 4, 16} {
+		parts := make([][]byte, numParts)
+		for i := range parts {
+			parts[i] = bytes.Repeat([]byte{'a'}, 16*(i+1))
+		}
+		b.Run(fmt.Sprintf("parts=%d", numParts), func(b *testing.B) {
+			b.Run("append", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					var dst []byte
+					for _, p := range parts {
+						dst = append(dst, p...)
+					}
+				}
+			})
+			b.Run("AppendMany", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_ = AppendMany(nil, parts...)
+				}
+			})
+			b.Run("AppendMany/reuse", func(b *testing.B) {
+				b.ReportAllocs()
+				var dst []byte
+				for i := 0; i < b.N; i++ {
+					dst = AppendMany(dst[:0], parts...)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crbytes
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// AppendPrefixCompressed appends the prefix-compressed encoding of the given
+// keys to dst. Each key is encoded as the length of the prefix it shares with
+// the previous key (a uvarint), the length of the rest of the key (a uvarint),
+// followed by the rest of the key. This is the key encoding used by LevelDB and
+// RocksDB data blocks (without restart points).
+//
+// The encoding works for any sequence of keys but is most effective when the
+// keys are sorted.
+func AppendPrefixCompressed(dst []byte, keys [][]byte) []byte {
+	var prev []byte
+	for _, k := range keys {
+		shared := CommonPrefix(prev, k)
+		dst = binary.AppendUvarint(dst, uint64(shared))
+		dst = binary.AppendUvarint(dst, uint64(len(k)-shared))
+		dst = append(dst, k[shared:]...)
+		prev = k
+	}
+	return dst
+}
+
+// ErrInvalidPrefixCompressed is returned by DecodePrefixCompressed when the
+// encoding is corrupt.
+var ErrInvalidPrefixCompressed = errors.New("crbytes: invalid prefix-compressed encoding")
+
+// DecodePrefixCompressed decodes the entirety of buf (which was encoded with
+// AppendPrefixCompressed) and returns the keys. The keys do not alias buf.
+//
+// Returns io.ErrUnexpectedEOF if buf is truncated, or
+// ErrInvalidPrefixCompressed if it is corrupt.
+func DecodePrefixCompressed(buf []byte) ([][]byte, error) {
+	var keys [][]byte
+	var prev []byte
+	for len(buf) > 0 {
+		shared, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, uvarintError(n)
+		}
+		buf = buf[n:]
+		unshared, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, uvarintError(n)
+		}
+		buf = buf[n:]
+		if shared > uint64(len(prev)) {
+			return nil, ErrInvalidPrefixCompressed
+		}
+		if unshared > uint64(len(buf)) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		k := make([]byte, shared+unshared)
+		copy(k, prev[:shared])
+		copy(k[shared:], buf[:unshared])
+		buf = buf[unshared:]
+		keys = append(keys, k)
+		prev = k
+	}
+	return keys, nil
+}
+
+// uvarintError returns the error corresponding to a non-positive result from
+// binary.Uvarint.
+func uvarintError(n int) error {
+	if n == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return ErrInvalidPrefixCompressed
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crbytes
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestPrefixCompressed(t *testing.T) {
+	check := func(keys [][]byte) []byte {
+		t.Helper()
+		buf := AppendPrefixCompressed(nil, keys)
+		res, err := DecodePrefixCompressed(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != len(keys) {
+			t.Fatalf("expected %d keys, got %d", len(keys), len(res))
+		}
+		for i := range keys {
+			if !bytes.Equal(res[i], keys[i]) {
+				t.Fatalf("key %d: expected %q, got %q", i, keys[i], res[i])
+			}
+		}
+		return buf
+	}
+	check(nil)
+	check([][]byte{{}})
+	check([][]byte{[]byte("a"), []byte("a"), []byte("ab"), []byte("b"), {}})
+	buf := check([][]byte{[]byte("apple"), []byte("apply"), []byte("apricot")})
+	if expected := "\x00\x05apple\x04\x01y\x02\x05ricot"; string(buf) != expected {
+		t.Errorf("expected %q, got %q", expected, buf)
+	}
+	for n := 0; n < 20; n++ {
+		keys := lexicographicSet(n, n+20)[:n*10]
+		check(keys)
+	}
+}
+
+func TestDecodePrefixCompressedErrors(t *testing.T) {
+	buf := AppendPrefixCompressed(nil, [][]byte{[]byte("apple"), []byte("apricot")})
+	// Truncating the buffer at a key boundary is valid.
+	boundary := len("\x00\x05apple")
+	for i := 1; i < len(buf); i++ {
+		if _, err := DecodePrefixCompressed(buf[:i]); (err != nil) != (i != boundary) {
+			t.Errorf("truncated at %d: unexpected error %v", i, err)
+		} else if i != boundary && err != io.ErrUnexpectedEOF {
+			t.Errorf("truncated at %d: unexpected error %v", i, err)
+		}
+	}
+	// The shared length exceeds the previous key.
+	if _, err := DecodePrefixCompressed([]byte("\x01\x01a")); err != ErrInvalidPrefixCompressed {
+		t.Errorf("unexpected error %v", err)
+	}
+	// A huge unshared length must not cause a large allocation.
+	if _, err := DecodePrefixCompressed([]byte("\x00\xff\xff\xff\xff\x0fa")); err != io.ErrUnexpectedEOF {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := DecodePrefixCompressed(bytes.Repeat([]byte{0xff}, 11)); err != ErrInvalidPrefixCompressed {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// BenchmarkPrefixCompressed measures encoding and decoding blocks of 16 sorted
+// keys. The "ratio" metric is the size of the encoding relative to the total
+// size of the keys.
+//
+// Sample benchmark results on linux/amd64, Intel(R) Xeon(R) Processor:
+//
+//	PrefixCompressed/small/encode    160ns   0.30 ratio
+//	PrefixCompressed/small/decode    771ns
+//	PrefixCompressed/medium/encode   347ns   0.68 ratio
+//	PrefixCompressed/medium/decode  1.25µs
+//	PrefixCompressed/large/encode   10.6µs   0.83 ratio
+//	PrefixCompressed/large/decode   23.3µs
+func BenchmarkPrefixCompressed(b *testing.B) {
+	for _, tc := range []struct {
+		name  string
+		input [][]byte
+	}{
+		{name: "small", input: lexicographicSet(4, 16)},
+		{name: "medium", input: lexicographicSet(10, 100)},
+		{name: "large", input: lexicographicSet(1000, 10000)},
+	} {
+		const blockSize = 16
+		var keysSize, encodedSize int
+		for i := 0; i+blockSize <= len(tc.input); i += blockSize {
+			block := tc.input[i : i+blockSize]
+			for _, k := range block {
+				keysSize += len(k)
+			}
+			encodedSize += len(AppendPrefixCompressed(nil, block))
+		}
+		ratio := float64(encodedSize) / float64(keysSize)
+		b.Run(tc.name, func(b *testing.B) {
+			var buf []byte
+			b.Run("encode", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					j := (i * blockSize) % (len(tc.input) - blockSize)
+					buf = AppendPrefixCompressed(buf[:0], tc.input[j:j+blockSize])
+				}
+				b.ReportMetric(ratio, "ratio")
+			})
+			b.Run("decode", func(b *testing.B) {
+				blocks := make([][]byte, 64)
+				for i := range blocks {
+					j := (i * blockSize) % (len(tc.input) - blockSize)
+					blocks[i] = AppendPrefixCompressed(nil, tc.input[j:j+blockSize])
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := DecodePrefixCompressed(blocks[i%len(blocks)]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
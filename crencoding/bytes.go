@@ -0,0 +1,48 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// AppendBytes appends the length-prefixed encoding of b to dst: the length as a
+// uvarint, followed by the bytes.
+func AppendBytes(dst, b []byte) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(b)))
+	return append(dst, b...)
+}
+
+// BytesEncodedLen returns the length of the AppendBytes encoding of b.
+func BytesEncodedLen(b []byte) int {
+	return UvarintLen64(uint64(len(b))) + len(b)
+}
+
+// DecodeBytes decodes a value encoded with AppendBytes and returns it along with
+// the remainder of the buffer. The returned slice aliases buf.
+//
+// Returns io.ErrUnexpectedEOF if the buffer is truncated, or ErrVarintOverflow
+// if the length prefix is invalid.
+func DecodeBytes(buf []byte) (b, rest []byte, err error) {
+	n, rest, err := decodeUvarint(buf)
+	if err != nil {
+		return nil, buf, err
+	}
+	if n > uint64(len(rest)) {
+		return nil, buf, io.ErrUnexpectedEOF
+	}
+	return rest[:n:n], rest[n:], nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestBytes(t *testing.T) {
+	var buf []byte
+	var values [][]byte
+	for i := 0; i < 100; i++ {
+		v := make([]byte, rand.IntN(300))
+		for j := range v {
+			v[j] = byte(rand.Uint32())
+		}
+		values = append(values, v)
+		before := len(buf)
+		buf = AppendBytes(buf, v)
+		require.Equal(t, len(buf)-before, BytesEncodedLen(v))
+	}
+	rest := buf
+	for _, v := range values {
+		var b []byte
+		var err error
+		b, rest, err = DecodeBytes(rest)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(b, v))
+	}
+	require.Equal(t, len(rest), 0)
+}
+
+func TestDecodeBytesErrors(t *testing.T) {
+	buf := AppendBytes(nil, []byte("hello"))
+	for i := 0; i < len(buf); i++ {
+		_, rest, err := DecodeBytes(buf[:i])
+		require.Equal(t, err, io.ErrUnexpectedEOF)
+		require.Equal(t, len(rest), i)
+	}
+	// A huge length must not cause a panic or an allocation.
+	huge := binary.AppendUvarint(nil, math.MaxUint64)
+	_, _, err := DecodeBytes(huge)
+	require.Equal(t, err, io.ErrUnexpectedEOF)
+
+	overflow := bytes.Repeat([]byte{0xff}, 11)
+	_, _, err = DecodeBytes(overflow)
+	require.Equal(t, err, ErrVarintOverflow)
+}
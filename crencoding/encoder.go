@@ -0,0 +1,143 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Encoder accumulates encoded values in a buffer which can be reused across
+// records (using Reset), avoiding allocations in serialization loops.
+//
+// The zero value is ready to use.
+type Encoder struct {
+	buf []byte
+}
+
+// Reset clears the encoded data, retaining the buffer for reuse.
+func (e *Encoder) Reset() {
+	e.buf = e.buf[:0]
+}
+
+// PutUvarint appends x as a uvarint.
+func (e *Encoder) PutUvarint(x uint64) {
+	e.buf = binary.AppendUvarint(e.buf, x)
+}
+
+// PutUint64BE appends x as 8 big-endian bytes.
+func (e *Encoder) PutUint64BE(x uint64) {
+	e.buf = binary.BigEndian.AppendUint64(e.buf, x)
+}
+
+// PutBytes appends b in length-prefixed form (see AppendBytes).
+func (e *Encoder) PutBytes(b []byte) {
+	e.buf = AppendBytes(e.buf, b)
+}
+
+// Len returns the length of the encoded data.
+func (e *Encoder) Len() int {
+	return len(e.buf)
+}
+
+// Bytes returns the encoded data. The result aliases the internal buffer; it is
+// only valid until the next call to a Put method or Reset.
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// Decoder decodes values written by an Encoder, in the same order.
+//
+// Errors are sticky: after a decoding error, all subsequent calls return zero
+// values and Err returns the first error (annotated with the offset at which it
+// occurred). It is thus sufficient to check Err once, after decoding all the
+// fields of a record.
+type Decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+// MakeDecoder returns a Decoder that reads from buf.
+func MakeDecoder(buf []byte) Decoder {
+	return Decoder{buf: buf}
+}
+
+// Uvarint decodes a uvarint.
+func (d *Decoder) Uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	x, rest, err := decodeUvarint(d.buf[d.pos:])
+	if err != nil {
+		d.setErr(err)
+		return 0
+	}
+	d.advance(rest)
+	return x
+}
+
+// Uint64BE decodes 8 big-endian bytes.
+func (d *Decoder) Uint64BE() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf)-d.pos < 8 {
+		d.setErr(io.ErrUnexpectedEOF)
+		return 0
+	}
+	x := binary.BigEndian.Uint64(d.buf[d.pos:])
+	d.pos += 8
+	return x
+}
+
+// Bytes decodes a length-prefixed byte slice. The result aliases the decoder's
+// buffer.
+func (d *Decoder) Bytes() []byte {
+	if d.err != nil {
+		return nil
+	}
+	b, rest, err := DecodeBytes(d.buf[d.pos:])
+	if err != nil {
+		d.setErr(err)
+		return nil
+	}
+	d.advance(rest)
+	return b
+}
+
+// Err returns the first error encountered, if any.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Pos returns the current offset in the buffer.
+func (d *Decoder) Pos() int {
+	return d.pos
+}
+
+// Remaining returns the number of bytes that have not been decoded yet.
+func (d *Decoder) Remaining() int {
+	return len(d.buf) - d.pos
+}
+
+func (d *Decoder) advance(rest []byte) {
+	d.pos = len(d.buf) - len(rest)
+}
+
+func (d *Decoder) setErr(err error) {
+	d.err = fmt.Errorf("crencoding: decoding at offset %d: %w", d.pos, err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestEncoderDecoder(t *testing.T) {
+	var e Encoder
+	for iter := 0; iter < 2; iter++ {
+		e.Reset()
+		e.PutUvarint(0)
+		e.PutUvarint(math.MaxUint64)
+		e.PutBytes([]byte("hello"))
+		e.PutUint64BE(0x0102030405060708)
+		e.PutBytes(nil)
+		require.Equal(t, e.Len(), len(e.Bytes()))
+
+		d := MakeDecoder(e.Bytes())
+		require.Equal(t, d.Uvarint(), 0)
+		require.Equal(t, d.Uvarint(), math.MaxUint64)
+		require.True(t, bytes.Equal(d.Bytes(), []byte("hello")))
+		require.Equal(t, d.Uint64BE(), 0x0102030405060708)
+		require.Equal(t, len(d.Bytes()), 0)
+		require.NoError(t, d.Err())
+		require.Equal(t, d.Remaining(), 0)
+		require.Equal(t, d.Pos(), e.Len())
+	}
+	// Reusing the encoder should not allocate.
+	allocs := testing.AllocsPerRun(100, func() {
+		e.Reset()
+		e.PutUvarint(1234)
+		e.PutBytes([]byte("hello"))
+	})
+	require.Equal(t, allocs, 0)
+}
+
+func TestDecoderErrors(t *testing.T) {
+	var e Encoder
+	e.PutUvarint(1)
+	e.PutBytes([]byte("hello"))
+	buf := e.Bytes()
+
+	d := MakeDecoder(buf[:len(buf)-1])
+	require.Equal(t, d.Uvarint(), 1)
+	require.Equal(t, d.Bytes(), nil)
+	require.True(t, errors.Is(d.Err(), io.ErrUnexpectedEOF))
+	require.Equal(t, d.Err().Error(), "crencoding: decoding at offset 1: unexpected EOF")
+	// Errors are sticky.
+	require.Equal(t, d.Uint64BE(), 0)
+	require.Equal(t, d.Uvarint(), 0)
+	require.Equal(t, d.Pos(), 1)
+
+	d = MakeDecoder(buf[:7])
+	d.Uint64BE()
+	require.True(t, errors.Is(d.Err(), io.ErrUnexpectedEOF))
+
+	d = MakeDecoder(bytes.Repeat([]byte{0xff}, 11))
+	d.Uvarint()
+	require.True(t, errors.Is(d.Err(), ErrVarintOverflow))
+}
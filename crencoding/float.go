@@ -0,0 +1,79 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// AppendFloat64Sortable appends an 8-byte encoding of f to dst, such that the
+// lexicographic order of the encodings matches the numeric order of the
+// values: -Inf < negative values < -0 < +0 < positive values < +Inf.
+//
+// The encoding is the IEEE 754 representation with the sign bit flipped for
+// non-negative values and all bits flipped for negative values. It is lossless:
+// -0 and +0 have distinct encodings (with -0 sorting immediately before +0) and
+// NaN payloads are preserved. NaNs with the sign bit set sort before -Inf and
+// all other NaNs (including math.NaN()) sort after +Inf; callers that need all
+// NaNs in a single position should canonicalize them before encoding.
+func AppendFloat64Sortable(dst []byte, f float64) []byte {
+	b := math.Float64bits(f)
+	if b&(1<<63) != 0 {
+		b = ^b
+	} else {
+		b |= 1 << 63
+	}
+	return binary.BigEndian.AppendUint64(dst, b)
+}
+
+// DecodeFloat64Sortable decodes a value encoded with AppendFloat64Sortable and
+// returns it along with the remainder of the buffer. Returns
+// io.ErrUnexpectedEOF if the buffer is too short.
+func DecodeFloat64Sortable(buf []byte) (f float64, rest []byte, err error) {
+	if len(buf) < 8 {
+		return 0, buf, io.ErrUnexpectedEOF
+	}
+	b := binary.BigEndian.Uint64(buf)
+	if b&(1<<63) != 0 {
+		b &^= 1 << 63
+	} else {
+		b = ^b
+	}
+	return math.Float64frombits(b), buf[8:], nil
+}
+
+// AppendFloat64SortableDesc is like AppendFloat64Sortable, except that the
+// lexicographic order of the encodings is the reverse of the numeric order.
+func AppendFloat64SortableDesc(dst []byte, f float64) []byte {
+	dst = AppendFloat64Sortable(dst, f)
+	b := dst[len(dst)-8:]
+	binary.BigEndian.PutUint64(b, ^binary.BigEndian.Uint64(b))
+	return dst
+}
+
+// DecodeFloat64SortableDesc decodes a value encoded with
+// AppendFloat64SortableDesc and returns it along with the remainder of the
+// buffer. Returns io.ErrUnexpectedEOF if the buffer is too short.
+func DecodeFloat64SortableDesc(buf []byte) (f float64, rest []byte, err error) {
+	if len(buf) < 8 {
+		return 0, buf, io.ErrUnexpectedEOF
+	}
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], ^binary.BigEndian.Uint64(buf))
+	f, _, _ = DecodeFloat64Sortable(tmp[:])
+	return f, buf[8:], nil
+}
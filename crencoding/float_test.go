@@ -0,0 +1,81 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestFloat64Sortable(t *testing.T) {
+	values := []float64{
+		math.Inf(-1), -math.MaxFloat64, -1e10, -1.5, -1, -math.SmallestNonzeroFloat64,
+		math.Copysign(0, -1), 0, math.SmallestNonzeroFloat64, 1, 1.5, 1e10,
+		math.MaxFloat64, math.Inf(+1),
+	}
+	for i := 0; i < 1000; i++ {
+		values = append(values, math.Float64frombits(rand.Uint64()))
+	}
+	// Remove NaNs; they are tested separately.
+	values = slices.DeleteFunc(values, math.IsNaN)
+
+	for _, a := range values {
+		encA := AppendFloat64Sortable([]byte("prefix"), a)
+		require.Equal(t, len(encA), len("prefix")+8)
+		dec, rest, err := DecodeFloat64Sortable(encA[len("prefix"):])
+		require.NoError(t, err)
+		require.Equal(t, len(rest), 0)
+		require.Equal(t, math.Float64bits(dec), math.Float64bits(a))
+
+		encDescA := AppendFloat64SortableDesc(nil, a)
+		dec, _, err = DecodeFloat64SortableDesc(encDescA)
+		require.NoError(t, err)
+		require.Equal(t, math.Float64bits(dec), math.Float64bits(a))
+
+		for _, b := range values {
+			encB := AppendFloat64Sortable(nil, b)
+			expected := 0
+			switch {
+			case a < b || (a == 0 && b == 0 && math.Signbit(a) && !math.Signbit(b)):
+				expected = -1
+			case a > b || (a == 0 && b == 0 && !math.Signbit(a) && math.Signbit(b)):
+				expected = 1
+			}
+			require.Equal(t, bytes.Compare(encA[len("prefix"):], encB), expected)
+			require.Equal(t, bytes.Compare(encDescA, AppendFloat64SortableDesc(nil, b)), -expected)
+		}
+	}
+}
+
+func TestFloat64SortableNaN(t *testing.T) {
+	nan := AppendFloat64Sortable(nil, math.NaN())
+	negNaN := AppendFloat64Sortable(nil, math.Copysign(math.NaN(), -1))
+	require.Equal(t, bytes.Compare(nan, AppendFloat64Sortable(nil, math.Inf(+1))), 1)
+	require.Equal(t, bytes.Compare(negNaN, AppendFloat64Sortable(nil, math.Inf(-1))), -1)
+	f, _, err := DecodeFloat64Sortable(nan)
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(f))
+
+	_, _, err = DecodeFloat64Sortable(nan[:7])
+	require.Equal(t, err, io.ErrUnexpectedEOF)
+	_, _, err = DecodeFloat64SortableDesc(nan[:7])
+	require.Equal(t, err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// AppendRLE appends the run-length encoding of src to dst. Each maximal run of
+// a repeated byte is encoded as the run length (a uvarint) followed by the byte
+// value. Runs longer than math.MaxInt32 are split into multiple runs, so that
+// the encoding can be decoded on 32-bit platforms.
+//
+// In the worst case (no two consecutive bytes are equal), the encoding is twice
+// the size of the input.
+func AppendRLE(dst, src []byte) []byte {
+	return appendRLE(dst, src, maxRLERun)
+}
+
+func appendRLE(dst, src []byte, maxRun int) []byte {
+	for len(src) > 0 {
+		n := runLen(src, maxRun)
+		dst = binary.AppendUvarint(dst, uint64(n))
+		dst = append(dst, src[0])
+		src = src[n:]
+	}
+	return dst
+}
+
+// RLEEncodedLen returns the length of the AppendRLE encoding of src.
+func RLEEncodedLen(src []byte) int {
+	return rleEncodedLen(src, maxRLERun)
+}
+
+func rleEncodedLen(src []byte, maxRun int) int {
+	l := 0
+	for len(src) > 0 {
+		n := runLen(src, maxRun)
+		l += UvarintLen64(uint64(n)) + 1
+		src = src[n:]
+	}
+	return l
+}
+
+// ErrInvalidRLE is returned when decoding an RLE encoding that contains an
+// invalid run length (zero or larger than math.MaxInt32).
+var ErrInvalidRLE = errors.New("crencoding: invalid RLE run length")
+
+// DecodeRLE decodes the entirety of src (which was encoded with AppendRLE) and
+// appends the result to dst.
+//
+// Returns io.ErrUnexpectedEOF if src is truncated, or ErrInvalidRLE or
+// ErrVarintOverflow if it is corrupt. Note that a short encoding can describe
+// a very long output; callers decoding untrusted input should validate the
+// expected length (e.g. by summing the run lengths) before decoding.
+func DecodeRLE(dst, src []byte) ([]byte, error) {
+	for len(src) > 0 {
+		n, rest, err := decodeUvarint(src)
+		if err != nil {
+			return dst, err
+		}
+		if n == 0 || n > maxRLERun {
+			return dst, ErrInvalidRLE
+		}
+		if len(rest) == 0 {
+			return dst, io.ErrUnexpectedEOF
+		}
+		dst = appendRepeated(dst, rest[0], int(n))
+		src = rest[1:]
+	}
+	return dst, nil
+}
+
+// maxRLERun is the maximum length of a run in the RLE encoding.
+const maxRLERun = math.MaxInt32
+
+// runLen returns the length of the run of bytes equal to src[0] at the start of
+// src, up to maxRun. src must not be empty.
+func runLen(src []byte, maxRun int) int {
+	n := 1
+	for n < len(src) && n < maxRun && src[n] == src[0] {
+		n++
+	}
+	return n
+}
+
+// appendRepeated appends n copies of b to dst.
+func appendRepeated(dst []byte, b byte, n int) []byte {
+	dst = append(dst, make([]byte, n)...)
+	tail := dst[len(dst)-n:]
+	for i := range tail {
+		tail[i] = b
+	}
+	return dst
+}
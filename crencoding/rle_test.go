@@ -0,0 +1,83 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestRLE(t *testing.T) {
+	check := func(src []byte) {
+		t.Helper()
+		enc := AppendRLE([]byte("prefix"), src)
+		require.Equal(t, string(enc[:6]), "prefix")
+		enc = enc[6:]
+		require.Equal(t, len(enc), RLEEncodedLen(src))
+		require.LE(t, len(enc), 2*len(src))
+		dec, err := DecodeRLE([]byte("x"), enc)
+		require.NoError(t, err)
+		require.Equal(t, string(dec), "x"+string(src))
+	}
+	check(nil)
+	check([]byte{0})
+	check([]byte("abc"))
+	check(make([]byte, 1000))
+	check(append(bytes.Repeat([]byte{1}, 200), bytes.Repeat([]byte{2}, 20000)...))
+	for i := 0; i < 100; i++ {
+		src := make([]byte, rand.IntN(1000))
+		for j := range src {
+			// Use a small alphabet so that there are some runs.
+			src[j] = byte(rand.IntN(3))
+		}
+		check(src)
+	}
+
+	require.Equal(t, AppendRLE(nil, []byte("aaab")), []byte{3, 'a', 1, 'b'})
+}
+
+// TestRLELongRuns checks that runs longer than the maximum run length are split
+// (using a small maximum, to avoid huge inputs).
+func TestRLELongRuns(t *testing.T) {
+	src := append(bytes.Repeat([]byte{1}, 10), bytes.Repeat([]byte{2}, 3)...)
+	enc := appendRLE(nil, src, 4)
+	require.Equal(t, enc, []byte{4, 1, 4, 1, 2, 1, 3, 2})
+	require.Equal(t, rleEncodedLen(src, 4), len(enc))
+	dec, err := DecodeRLE(nil, enc)
+	require.NoError(t, err)
+	require.Equal(t, dec, src)
+}
+
+func TestDecodeRLEErrors(t *testing.T) {
+	enc := AppendRLE(nil, bytes.Repeat([]byte{7}, 1000))
+	for i := 1; i < len(enc); i++ {
+		_, err := DecodeRLE(nil, enc[:i])
+		require.Equal(t, err, io.ErrUnexpectedEOF)
+	}
+	_, err := DecodeRLE(nil, []byte{0, 'a'})
+	require.Equal(t, err, ErrInvalidRLE)
+	_, err = DecodeRLE(nil, append(binary.AppendUvarint(nil, math.MaxUint64), 'a'))
+	require.Equal(t, err, ErrInvalidRLE)
+	_, err = DecodeRLE(nil, append(binary.AppendUvarint(nil, math.MaxInt32+1), 'a'))
+	require.Equal(t, err, ErrInvalidRLE)
+	_, err = DecodeRLE(nil, bytes.Repeat([]byte{0xff}, 11))
+	require.Equal(t, err, ErrVarintOverflow)
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+// SizeBuilder accumulates the encoded size of a sequence of fields, for
+// example to size a buffer for protobuf-style marshaling so that it can be
+// allocated exactly once. The zero value is ready to use.
+type SizeBuilder struct {
+	size int
+}
+
+// AddUvarint adds the size of the uvarint encoding of x.
+func (sb *SizeBuilder) AddUvarint(x uint64) {
+	sb.size += UvarintLen64(x)
+}
+
+// AddBytes adds the size of the length-prefixed encoding of b (see
+// AppendBytes).
+func (sb *SizeBuilder) AddBytes(b []byte) {
+	sb.size += BytesEncodedLen(b)
+}
+
+// AddTag adds the size of a protobuf field tag with the given field number and
+// wire type; the tag is encoded as the uvarint fieldNum<<3 | wireType.
+func (sb *SizeBuilder) AddTag(fieldNum uint32, wireType uint8) {
+	sb.size += UvarintLen64(uint64(fieldNum)<<3 | uint64(wireType&7))
+}
+
+// Size returns the total size of all the added fields.
+func (sb *SizeBuilder) Size() int {
+	return sb.size
+}
+
+// Reset resets the size to zero.
+func (sb *SizeBuilder) Reset() {
+	sb.size = 0
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestSizeBuilder(t *testing.T) {
+	var sb SizeBuilder
+	require.Equal(t, sb.Size(), 0)
+	sb.AddTag(1, 0)
+	require.Equal(t, sb.Size(), 1)
+	sb.AddTag(16, 2)
+	require.Equal(t, sb.Size(), 3)
+	sb.Reset()
+	require.Equal(t, sb.Size(), 0)
+
+	// Build a random message and compare the size with the actual encoding.
+	for n := 0; n < 100; n++ {
+		sb.Reset()
+		var buf []byte
+		for i := 0; i < rand.IntN(20); i++ {
+			fieldNum := uint32(rand.Int64N(1 << 29))
+			if rand.IntN(2) == 0 {
+				buf = binary.AppendUvarint(buf, uint64(fieldNum)<<3|0)
+				sb.AddTag(fieldNum, 0)
+				x := rand.Uint64() >> rand.IntN(64)
+				buf = binary.AppendUvarint(buf, x)
+				sb.AddUvarint(x)
+			} else {
+				buf = binary.AppendUvarint(buf, uint64(fieldNum)<<3|2)
+				sb.AddTag(fieldNum, 2)
+				b := make([]byte, rand.IntN(200))
+				buf = AppendBytes(buf, b)
+				sb.AddBytes(b)
+			}
+		}
+		require.Equal(t, sb.Size(), len(buf))
+	}
+	sb.Reset()
+	sb.AddTag(math.MaxUint32, 7)
+	require.Equal(t, sb.Size(), len(binary.AppendUvarint(nil, math.MaxUint32<<3|7)))
+}
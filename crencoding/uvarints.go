@@ -0,0 +1,64 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// AppendUvarints appends the encoding of values to dst: the number of values
+// as a uvarint, followed by each value as a uvarint.
+func AppendUvarints(dst []byte, values []uint64) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(values)))
+	for _, v := range values {
+		dst = binary.AppendUvarint(dst, v)
+	}
+	return dst
+}
+
+// UvarintsLen returns the length of the AppendUvarints encoding of values.
+func UvarintsLen(values []uint64) int {
+	n := UvarintLen64(uint64(len(values)))
+	for _, v := range values {
+		n += UvarintLen64(v)
+	}
+	return n
+}
+
+// DecodeUvarints decodes values encoded with AppendUvarints and returns them
+// along with the remainder of the buffer.
+//
+// Returns io.ErrUnexpectedEOF if the buffer is truncated, or ErrVarintOverflow
+// if it contains an invalid varint. The count is validated against the size of
+// the buffer before allocating, so corrupt input cannot cause a large
+// allocation.
+func DecodeUvarints(buf []byte) (values []uint64, rest []byte, err error) {
+	n, rest, err := decodeUvarint(buf)
+	if err != nil {
+		return nil, buf, err
+	}
+	// Each value takes at least one byte.
+	if n > uint64(len(rest)) {
+		return nil, buf, io.ErrUnexpectedEOF
+	}
+	values = make([]uint64, n)
+	for i := range values {
+		if values[i], rest, err = decodeUvarint(rest); err != nil {
+			return nil, buf, err
+		}
+	}
+	return values, rest, nil
+}
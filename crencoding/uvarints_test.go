@@ -0,0 +1,68 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crencoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestUvarints(t *testing.T) {
+	check := func(values []uint64) {
+		t.Helper()
+		buf := AppendUvarints([]byte("x"), values)
+		require.Equal(t, len(buf)-1, UvarintsLen(values))
+		buf = append(buf, "rest"...)
+		res, rest, err := DecodeUvarints(buf[1:])
+		require.NoError(t, err)
+		require.Equal(t, len(res), len(values))
+		for i := range values {
+			require.Equal(t, res[i], values[i])
+		}
+		require.Equal(t, string(rest), "rest")
+	}
+	check(nil)
+	check([]uint64{0})
+	check([]uint64{1, 127, 128, math.MaxUint32, math.MaxUint64})
+	for i := 0; i < 100; i++ {
+		values := make([]uint64, rand.IntN(100))
+		for j := range values {
+			values[j] = rand.Uint64() >> rand.IntN(64)
+		}
+		check(values)
+	}
+}
+
+func TestDecodeUvarintsErrors(t *testing.T) {
+	buf := AppendUvarints(nil, []uint64{1, 1000, math.MaxUint64})
+	for i := 0; i < len(buf); i++ {
+		_, rest, err := DecodeUvarints(buf[:i])
+		require.Equal(t, err, io.ErrUnexpectedEOF)
+		require.Equal(t, len(rest), i)
+	}
+	// A huge count must not cause a panic or a large allocation.
+	huge := binary.AppendUvarint(nil, math.MaxUint64)
+	_, _, err := DecodeUvarints(append(huge, 1, 2, 3))
+	require.Equal(t, err, io.ErrUnexpectedEOF)
+
+	_, _, err = DecodeUvarints(append([]byte{1}, bytes.Repeat([]byte{0xff}, 11)...))
+	require.Equal(t, err, ErrVarintOverflow)
+}
@@ -14,7 +14,12 @@
 
 package crencoding
 
-import "math/bits"
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
 
 // UvarintLen32 returns the number of bytes necessary for the Go
 // encoding/binary.Uvarint encoding.
@@ -41,3 +46,45 @@ func UvarintLen64(x uint64) int {
 	// small values in the range we care about.
 	return int((b * 37) >> 8)
 }
+
+// ErrVarintOverflow is returned when decoding a varint which does not fit in 64
+// bits.
+var ErrVarintOverflow = errors.New("crencoding: varint overflows a 64-bit integer")
+
+// decodeUvarint decodes a uvarint from the beginning of buf and returns it
+// along with the remainder of the buffer.
+func decodeUvarint(buf []byte) (x uint64, rest []byte, err error) {
+	x, n := binary.Uvarint(buf)
+	switch {
+	case n == 0:
+		return 0, buf, io.ErrUnexpectedEOF
+	case n < 0:
+		return 0, buf, ErrVarintOverflow
+	}
+	return x, buf[n:], nil
+}
+
+// ErrNonCanonicalVarint is returned by DecodeUvarintCanonical when a varint is
+// not encoded in its minimal form.
+var ErrNonCanonicalVarint = errors.New("crencoding: non-canonical varint encoding")
+
+// DecodeUvarintCanonical decodes a uvarint from the beginning of buf and returns
+// it along with the remainder of the buffer. Unlike binary.Uvarint, it rejects
+// overlong encodings (e.g. 0x80 0x00 for zero): each value has exactly one
+// accepted encoding, the one produced by binary.AppendUvarint.
+//
+// Returns io.ErrUnexpectedEOF if the buffer is truncated, ErrVarintOverflow if
+// the value does not fit in 64 bits, or ErrNonCanonicalVarint if the encoding
+// is not minimal.
+func DecodeUvarintCanonical(buf []byte) (x uint64, rest []byte, err error) {
+	x, rest, err = decodeUvarint(buf)
+	if err != nil {
+		return 0, buf, err
+	}
+	// The encoding is minimal unless it has more than one byte and the last byte
+	// (the only one without the continuation bit) is zero.
+	if n := len(buf) - len(rest); n > 1 && buf[n-1] == 0 {
+		return 0, buf, ErrNonCanonicalVarint
+	}
+	return x, rest, nil
+}
@@ -16,6 +16,7 @@ package crencoding
 
 import (
 	"encoding/binary"
+	"io"
 	"math"
 	"math/rand/v2"
 	"testing"
@@ -44,3 +45,41 @@ func TestUvarintLen(t *testing.T) {
 		check(rand.Uint64() >> rand.UintN(64))
 	}
 }
+
+func TestDecodeUvarintCanonical(t *testing.T) {
+	check := func(x uint64) {
+		t.Helper()
+		buf := binary.AppendUvarint(nil, x)
+		buf = append(buf, 0xff)
+		res, rest, err := DecodeUvarintCanonical(buf)
+		require.NoError(t, err)
+		require.Equal(t, res, x)
+		require.Equal(t, rest, []byte{0xff})
+	}
+	for _, x := range []uint64{0, 1, 127, 128, 255, 16383, 16384, math.MaxUint32, math.MaxUint64} {
+		check(x)
+	}
+	for i := 0; i < 1000; i++ {
+		check(rand.Uint64() >> rand.IntN(64))
+	}
+
+	// Overlong encodings.
+	for _, buf := range [][]byte{
+		{0x80, 0x00},
+		{0x81, 0x00},
+		{0xff, 0x80, 0x00},
+		{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00},
+	} {
+		// binary.Uvarint accepts these.
+		_, n := binary.Uvarint(buf)
+		require.Equal(t, n, len(buf))
+		_, rest, err := DecodeUvarintCanonical(buf)
+		require.Equal(t, err, ErrNonCanonicalVarint)
+		require.Equal(t, len(rest), len(buf))
+	}
+
+	_, _, err := DecodeUvarintCanonical([]byte{0x80})
+	require.Equal(t, err, io.ErrUnexpectedEOF)
+	_, _, err = DecodeUvarintCanonical([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00})
+	require.Equal(t, err, ErrVarintOverflow)
+}
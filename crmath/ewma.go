@@ -0,0 +1,77 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import "math"
+
+// EWMA is an exponentially weighted moving average of a series of samples. It
+// is not safe for concurrent use.
+//
+// Before the first sample is added, Value returns 0. The first sample
+// initializes the average directly (instead of being blended with the initial
+// zero value), so early values are not biased toward zero.
+type EWMA struct {
+	alpha       float64
+	value       float64
+	initialized bool
+}
+
+// MakeEWMA returns an EWMA with the given smoothing factor, which must be in
+// the range (0, 1]. A larger alpha gives more weight to recent samples; an
+// alpha of 1 makes the average equal to the last sample.
+func MakeEWMA(alpha float64) EWMA {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("invalid alpha")
+	}
+	return EWMA{alpha: alpha}
+}
+
+// Add incorporates a new sample into the average.
+func (e *EWMA) Add(sample float64) {
+	if !e.initialized {
+		e.value = sample
+		e.initialized = true
+		return
+	}
+	e.value += e.alpha * (sample - e.value)
+}
+
+// AddWithWeight incorporates a new sample with the given weight, which is
+// useful when samples are taken at irregular intervals: a sample with weight w
+// has the same effect as w consecutive Add calls with the same sample. For
+// example, the weight can be the time since the last sample divided by the
+// nominal sampling interval.
+//
+// The weight must be non-negative (and not NaN); a zero weight has no effect.
+func (e *EWMA) AddWithWeight(sample float64, weight float64) {
+	if !(weight >= 0) {
+		panic("invalid weight")
+	}
+	if weight == 0 {
+		return
+	}
+	if !e.initialized {
+		e.value = sample
+		e.initialized = true
+		return
+	}
+	alpha := 1 - math.Pow(1-e.alpha, weight)
+	e.value += alpha * (sample - e.value)
+}
+
+// Value returns the current average, or 0 if no samples were added.
+func (e *EWMA) Value() float64 {
+	return e.value
+}
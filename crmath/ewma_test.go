@@ -0,0 +1,67 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestEWMA(t *testing.T) {
+	e := MakeEWMA(0.5)
+	require.Equal(t, e.Value(), 0)
+	e.Add(10)
+	require.Equal(t, e.Value(), 10)
+	e.Add(20)
+	require.Equal(t, e.Value(), 15)
+	e.Add(15)
+	require.Equal(t, e.Value(), 15)
+
+	e = MakeEWMA(1)
+	e.Add(1)
+	e.Add(7)
+	require.Equal(t, e.Value(), 7)
+}
+
+func TestEWMAWithWeight(t *testing.T) {
+	e := MakeEWMA(0.1)
+	e.AddWithWeight(5, 0)
+	require.Equal(t, e.Value(), 0)
+	e.AddWithWeight(5, 2)
+	require.Equal(t, e.Value(), 5)
+
+	// A sample with weight 3 should be equivalent to adding it three times.
+	a, b := MakeEWMA(0.1), MakeEWMA(0.1)
+	a.Add(1)
+	b.Add(1)
+	for i := 0; i < 3; i++ {
+		a.Add(100)
+	}
+	b.AddWithWeight(100, 3)
+	require.LE(t, math.Abs(a.Value()-b.Value()), 1e-9)
+
+	// Invalid weights panic (and don't affect the average).
+	for _, w := range []float64{-1, math.NaN()} {
+		func() {
+			defer func() {
+				require.NotEqual(t, recover(), nil)
+			}()
+			b.AddWithWeight(100, w)
+		}()
+		require.LE(t, math.Abs(a.Value()-b.Value()), 1e-9)
+	}
+}
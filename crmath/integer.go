@@ -0,0 +1,97 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// RoundUpToMultiple returns the smallest multiple of the given value which is
+// greater than or equal to v. For example, RoundUpToMultiple(10, 4) is 12 and
+// RoundUpToMultiple(-10, 4) is -8.
+//
+// Panics if multiple is not positive or if the result overflows T.
+func RoundUpToMultiple[T Integer](v, multiple T) T {
+	r := remainder(v, multiple)
+	if r == 0 {
+		return v
+	}
+	// We don't use the (v + multiple - 1) / multiple * multiple idiom because the
+	// intermediate value can overflow even if the result does not.
+	res := v + (multiple - r)
+	if res < v {
+		panic("overflow")
+	}
+	return res
+}
+
+// RoundDownToMultiple returns the largest multiple of the given value which is
+// less than or equal to v. For example, RoundDownToMultiple(10, 4) is 8 and
+// RoundDownToMultiple(-10, 4) is -12.
+//
+// Panics if multiple is not positive or if the result overflows T.
+func RoundDownToMultiple[T Integer](v, multiple T) T {
+	res := v - remainder(v, multiple)
+	if res > v {
+		panic("overflow")
+	}
+	return res
+}
+
+// remainder returns v modulo m, in the range [0, m).
+func remainder[T Integer](v, m T) T {
+	if m <= 0 {
+		panic("multiple must be positive")
+	}
+	r := v % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// CheckedAdd returns a+b and true, or (an unspecified value and) false if the
+// addition overflows T.
+func CheckedAdd[T Integer](a, b T) (T, bool) {
+	s := a + b
+	if isSigned[T]() {
+		// Overflow is only possible when the operands have the same sign, in
+		// which case it manifests as a result of the opposite sign.
+		return s, (a < 0) != (b < 0) || (s < 0) == (a < 0)
+	}
+	return s, s >= a
+}
+
+// CheckedMul returns a*b and true, or (an unspecified value and) false if the
+// multiplication overflows T.
+func CheckedMul[T Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	if b < 0 && b+1 == 0 {
+		// The division check below doesn't work in this case: the only overflow
+		// is MinInt * -1, which wraps to MinInt, and MinInt / -1 is also MinInt.
+		return p, a != p
+	}
+	return p, p/b == a
+}
+
+// isSigned returns true if T is a signed integer type.
+func isSigned[T Integer]() bool {
+	var zero T
+	return zero-1 < zero
+}
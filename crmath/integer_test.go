@@ -0,0 +1,158 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestRoundToMultiple(t *testing.T) {
+	require.Equal(t, RoundUpToMultiple(10, 4), 12)
+	require.Equal(t, RoundUpToMultiple(12, 4), 12)
+	require.Equal(t, RoundUpToMultiple(-10, 4), -8)
+	require.Equal(t, RoundDownToMultiple(10, 4), 8)
+	require.Equal(t, RoundDownToMultiple(-10, 4), -12)
+	require.Equal(t, RoundDownToMultiple(-12, 4), -12)
+	require.Equal(t, RoundUpToMultiple(uint64(math.MaxUint64-3), 5), math.MaxUint64)
+	require.Equal(t, RoundDownToMultiple(int64(math.MinInt64+10), 2), math.MinInt64+10)
+}
+
+// TestRoundToMultipleExhaustive checks all int8 and uint8 values and multiples
+// against a computation in a wider type.
+func TestRoundToMultipleExhaustive(t *testing.T) {
+	floorDiv := func(a, b int) int {
+		q := a / b
+		if a%b != 0 && a < 0 {
+			q--
+		}
+		return q
+	}
+	check := func(v, m, minVal, maxVal int, up, down func() int) {
+		expectedDown := floorDiv(v, m) * m
+		expectedUp := expectedDown
+		if expectedUp < v {
+			expectedUp += m
+		}
+		for _, c := range []struct {
+			expected int
+			fn       func() int
+		}{
+			{expected: expectedUp, fn: up},
+			{expected: expectedDown, fn: down},
+		} {
+			if c.expected < minVal || c.expected > maxVal {
+				func() {
+					defer func() {
+						if r := recover(); r == nil {
+							t.Fatalf("v=%d m=%d: expected overflow panic", v, m)
+						}
+					}()
+					c.fn()
+				}()
+			} else if res := c.fn(); res != c.expected {
+				t.Fatalf("v=%d m=%d: expected %d, got %d", v, m, c.expected, res)
+			}
+		}
+	}
+	for v := math.MinInt8; v <= math.MaxInt8; v++ {
+		for m := 1; m <= math.MaxInt8; m++ {
+			check(v, m, math.MinInt8, math.MaxInt8,
+				func() int { return int(RoundUpToMultiple(int8(v), int8(m))) },
+				func() int { return int(RoundDownToMultiple(int8(v), int8(m))) },
+			)
+		}
+	}
+	for v := 0; v <= math.MaxUint8; v++ {
+		for m := 1; m <= math.MaxUint8; m++ {
+			check(v, m, 0, math.MaxUint8,
+				func() int { return int(RoundUpToMultiple(uint8(v), uint8(m))) },
+				func() int { return int(RoundDownToMultiple(uint8(v), uint8(m))) },
+			)
+		}
+	}
+}
+
+func TestRoundToMultipleInvalid(t *testing.T) {
+	for _, m := range []int{0, -1} {
+		func() {
+			defer func() {
+				require.NotEqual(t, recover(), nil)
+			}()
+			RoundUpToMultiple(10, m)
+		}()
+	}
+}
+
+// TestCheckedExhaustive checks CheckedAdd and CheckedMul for all int8 and uint8
+// pairs against a computation in a wider type.
+func TestCheckedExhaustive(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			checkChecked(t, int8(a), int8(b), a+b, a*b, math.MinInt8, math.MaxInt8)
+		}
+	}
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			checkChecked(t, uint8(a), uint8(b), a+b, a*b, 0, math.MaxUint8)
+		}
+	}
+}
+
+func checkChecked[T Integer](t *testing.T, a, b T, sum, prod, minVal, maxVal int) {
+	t.Helper()
+	res, ok := CheckedAdd(a, b)
+	if expOk := sum >= minVal && sum <= maxVal; ok != expOk || (ok && int(res) != sum) {
+		t.Fatalf("CheckedAdd(%d, %d) = %d, %t; expected %d", a, b, res, ok, sum)
+	}
+	res, ok = CheckedMul(a, b)
+	if expOk := prod >= minVal && prod <= maxVal; ok != expOk || (ok && int(res) != prod) {
+		t.Fatalf("CheckedMul(%d, %d) = %d, %t; expected %d", a, b, res, ok, prod)
+	}
+}
+
+func TestChecked(t *testing.T) {
+	check := func(v int64, ok bool, expV int64, expOk bool) {
+		t.Helper()
+		require.Equal(t, ok, expOk)
+		if ok {
+			require.Equal(t, v, expV)
+		}
+	}
+	v, ok := CheckedAdd(int64(math.MaxInt64-1), 1)
+	check(v, ok, math.MaxInt64, true)
+	v, ok = CheckedAdd(int64(math.MaxInt64), 1)
+	check(v, ok, 0, false)
+	v, ok = CheckedAdd(int64(math.MinInt64), -1)
+	check(v, ok, 0, false)
+	v, ok = CheckedMul(int64(math.MinInt64), -1)
+	check(v, ok, 0, false)
+	v, ok = CheckedMul(int64(math.MaxInt64), -1)
+	check(v, ok, -math.MaxInt64, true)
+	v, ok = CheckedMul(int64(1)<<32, 1<<31)
+	check(v, ok, 0, false)
+	v, ok = CheckedMul(int64(1)<<31, 1<<31)
+	check(v, ok, 1<<62, true)
+
+	u, ok := CheckedAdd(uint64(math.MaxUint64), 1)
+	require.False(t, ok)
+	u, ok = CheckedMul(uint64(1)<<32, 1<<32)
+	require.False(t, ok)
+	u, ok = CheckedMul(uint64(1)<<32, 1<<31)
+	require.True(t, ok)
+	require.Equal(t, u, 1<<63)
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+// Mix64 scrambles the bits of x using the splitmix64 finalizer: each bit of the
+// input affects each bit of the output with probability close to 1/2. It is
+// useful for hashing integers, combining hashes and deriving seeds.
+//
+// Mix64 is a bijection (so distinct inputs produce distinct outputs) and
+// Mix64(0) = 0. It is not a cryptographic hash.
+func Mix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// Mix32 is the 32-bit analog of Mix64, using the murmur3 finalizer.
+//
+// Mix32 is a bijection and Mix32(0) = 0. It is not a cryptographic hash.
+func Mix32(x uint32) uint32 {
+	x = (x ^ (x >> 16)) * 0x85ebca6b
+	x = (x ^ (x >> 13)) * 0xc2b2ae35
+	return x ^ (x >> 16)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestMix(t *testing.T) {
+	// Reference values from the splitmix64 and murmur3 finalizers.
+	require.Equal(t, Mix64(0), 0)
+	require.Equal(t, Mix64(1), 0x5692161d100b05e5)
+	require.Equal(t, Mix32(0), 0)
+	require.Equal(t, Mix32(1), 0x514e28b7)
+}
+
+// TestMixAvalanche checks that flipping any input bit flips each output bit
+// with probability close to 1/2.
+func TestMixAvalanche(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	const numSamples = 2000
+	check := func(bitWidth int, mix func(x uint64) uint64) {
+		t.Helper()
+		var counts [64][64]int
+		for i := 0; i < numSamples; i++ {
+			x := rng.Uint64() >> (64 - bitWidth)
+			y := mix(x)
+			for in := 0; in < bitWidth; in++ {
+				diff := y ^ mix(x^(1<<in))
+				for out := 0; out < bitWidth; out++ {
+					counts[in][out] += int(diff>>out) & 1
+				}
+			}
+		}
+		// With 2000 samples, the standard deviation of each frequency is ~0.011.
+		for in := 0; in < bitWidth; in++ {
+			for out := 0; out < bitWidth; out++ {
+				if p := float64(counts[in][out]) / numSamples; p < 0.44 || p > 0.56 {
+					t.Fatalf("input bit %d flips output bit %d with probability %.3f", in, out, p)
+				}
+			}
+		}
+	}
+	check(64, Mix64)
+	check(32, func(x uint64) uint64 { return uint64(Mix32(uint32(x))) })
+}
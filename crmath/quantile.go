@@ -0,0 +1,60 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math"
+	"slices"
+
+	"github.com/cockroachdb/crlib/internal/invariants"
+)
+
+// Quantile returns the q-quantile of the given values, which must be sorted in
+// increasing order. The quantile is computed using linear interpolation between
+// the two closest order statistics (the same method as R's default and
+// NumPy's "linear" method); for example, the 0.5-quantile of [1, 2, 3, 4] is
+// 2.5.
+//
+// Quantile panics if q is not in the range [0, 1]. Returns NaN if the slice is
+// empty.
+func Quantile(sorted []float64, q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		panic("quantile must be in [0, 1]")
+	}
+	if invariants.Enabled && !slices.IsSorted(sorted) {
+		panic("values are not sorted")
+	}
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	h := float64(len(sorted)-1) * q
+	lo := int(h)
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// Percentile returns the p-th percentile of the given values, which must be
+// sorted in increasing order. It is equivalent to Quantile(sorted, p/100).
+//
+// Percentile panics if p is not in the range [0, 100]. Returns NaN if the slice
+// is empty.
+func Percentile(sorted []float64, p float64) float64 {
+	if !(p >= 0 && p <= 100) {
+		panic("percentile must be in [0, 100]")
+	}
+	return Quantile(sorted, p/100)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestQuantile(t *testing.T) {
+	require.True(t, math.IsNaN(Quantile(nil, 0.5)))
+
+	require.Equal(t, Quantile([]float64{7}, 0), 7)
+	require.Equal(t, Quantile([]float64{7}, 1), 7)
+
+	values := []float64{1, 2, 3, 4}
+	for _, tc := range []struct {
+		q, expected float64
+	}{
+		{0, 1},
+		{0.25, 1.75},
+		{0.5, 2.5},
+		{1.0 / 3, 2},
+		{0.9, 3.7},
+		{1, 4},
+	} {
+		res := Quantile(values, tc.q)
+		require.LE(t, math.Abs(res-tc.expected), 1e-12)
+		require.LE(t, math.Abs(Percentile(values, tc.q*100)-res), 1e-12)
+	}
+}
+
+func TestQuantileInvalid(t *testing.T) {
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		func() {
+			defer func() {
+				require.NotEqual(t, recover(), nil)
+			}()
+			Quantile([]float64{1, 2}, q)
+		}()
+	}
+}
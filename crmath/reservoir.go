@@ -0,0 +1,75 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// ReservoirSampler maintains a uniform random sample of fixed size over an
+// unbounded stream of values, which can be used to estimate quantiles of the
+// stream without storing all the values. It is not safe for concurrent use.
+//
+// The sampler uses a deterministically seeded random number generator, so the
+// same sequence of values always produces the same sample.
+type ReservoirSampler struct {
+	rng    *rand.Rand
+	sample []float64
+	count  int64
+	// sorted is scratch space used by Quantile.
+	sorted []float64
+}
+
+// MakeReservoirSampler returns a sampler that retains (at most) size values,
+// using the given seed for its random number generator.
+func MakeReservoirSampler(size int, seed uint64) ReservoirSampler {
+	if size <= 0 {
+		panic("invalid size")
+	}
+	return ReservoirSampler{
+		rng:    rand.New(rand.NewPCG(seed, 0)),
+		sample: make([]float64, 0, size),
+	}
+}
+
+// Add incorporates a new value from the stream. Each value seen so far has the
+// same probability of being in the sample.
+func (r *ReservoirSampler) Add(v float64) {
+	r.count++
+	if len(r.sample) < cap(r.sample) {
+		r.sample = append(r.sample, v)
+		return
+	}
+	if i := r.rng.Int64N(r.count); i < int64(len(r.sample)) {
+		r.sample[i] = v
+	}
+}
+
+// Count returns the total number of values added to the sampler.
+func (r *ReservoirSampler) Count() int64 {
+	return r.count
+}
+
+// Quantile returns an estimate of the q-quantile of the values added so far,
+// computed (as with the Quantile function) over the current sample. Returns
+// NaN if no values were added.
+//
+// Quantile panics if q is not in the range [0, 1].
+func (r *ReservoirSampler) Quantile(q float64) float64 {
+	r.sorted = append(r.sorted[:0], r.sample...)
+	slices.Sort(r.sorted)
+	return Quantile(r.sorted, q)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestReservoirSampler(t *testing.T) {
+	r := MakeReservoirSampler(10, 1)
+	require.True(t, math.IsNaN(r.Quantile(0.5)))
+	// While the stream is smaller than the reservoir, quantiles are exact.
+	for i := 1; i <= 5; i++ {
+		r.Add(float64(i))
+	}
+	require.Equal(t, r.Count(), 5)
+	require.Equal(t, r.Quantile(0), 1)
+	require.Equal(t, r.Quantile(0.5), 3)
+	require.Equal(t, r.Quantile(1), 5)
+
+	// Estimate quantiles of a large uniform stream.
+	r = MakeReservoirSampler(1000, 1)
+	rng := rand.New(rand.NewPCG(1, 2))
+	const n = 100_000
+	for i := 0; i < n; i++ {
+		r.Add(rng.Float64())
+	}
+	require.Equal(t, r.Count(), n)
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		require.LE(t, math.Abs(r.Quantile(q)-q), 0.05)
+	}
+
+	// The sample is deterministic for a given seed.
+	a, b := MakeReservoirSampler(10, 5), MakeReservoirSampler(10, 5)
+	for i := 0; i < 1000; i++ {
+		a.Add(float64(i))
+		b.Add(float64(i))
+	}
+	require.Equal(t, a.sample, b.sample)
+}
+
+// TestReservoirSamplerUniform checks that each value in the stream ends up in
+// the sample with roughly the same probability.
+func TestReservoirSamplerUniform(t *testing.T) {
+	const size = 5
+	const streamLen = 20
+	const numRuns = 20000
+	var counts [streamLen]int
+	for run := 0; run < numRuns; run++ {
+		r := MakeReservoirSampler(size, uint64(run))
+		for i := 0; i < streamLen; i++ {
+			r.Add(float64(i))
+		}
+		for _, v := range r.sample {
+			counts[int(v)]++
+		}
+	}
+	// The expected count is numRuns * size / streamLen = 5000, with a standard
+	// deviation of ~61.
+	for i, c := range counts {
+		if c < 4700 || c > 5300 {
+			t.Errorf("value %d sampled %d times", i, c)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// ProportionalSplit divides total into parts proportional to the given weights,
+// such that the parts sum up exactly to total. Each part is the corresponding
+// proportional share rounded down, and the remaining units are distributed (one
+// each) to the parts with the largest fractional remainders (the "largest
+// remainder method"); ties are broken in favor of earlier parts.
+//
+// The weights must be non-negative. If all weights are zero, total is split as
+// if all weights were equal. A negative total is split as the negation of the
+// split of -total.
+//
+// The computation uses 128-bit intermediate values so it does not overflow.
+func ProportionalSplit(total int64, weights []int64) []int64 {
+	if len(weights) == 0 {
+		if total != 0 {
+			panic("no weights")
+		}
+		return nil
+	}
+	// Work with the magnitude of the total; note that this is correct even for
+	// MinInt64.
+	magnitude := uint64(total)
+	if total < 0 {
+		magnitude = -magnitude
+	}
+	parts := splitUint64(magnitude, weights)
+	if total < 0 {
+		for i := range parts {
+			parts[i] = -parts[i]
+		}
+	}
+	res := make([]int64, len(parts))
+	for i := range parts {
+		res[i] = int64(parts[i])
+	}
+	return res
+}
+
+func splitUint64(total uint64, weights []int64) []uint64 {
+	var sum uint64
+	for _, w := range weights {
+		if w < 0 {
+			panic("negative weight")
+		}
+		var carry uint64
+		sum, carry = bits.Add64(sum, uint64(w), 0)
+		if carry != 0 {
+			panic("sum of weights overflows")
+		}
+	}
+	parts := make([]uint64, len(weights))
+	if sum == 0 {
+		// Split evenly.
+		n := uint64(len(parts))
+		for i := range parts {
+			parts[i] = total / n
+			if uint64(i) < total%n {
+				parts[i]++
+			}
+		}
+		return parts
+	}
+
+	remainders := make([]uint64, len(weights))
+	remaining := total
+	for i, w := range weights {
+		// Since w <= sum, the quotient fits in 64 bits (and hi < sum).
+		hi, lo := bits.Mul64(total, uint64(w))
+		q, r := bits.Div64(hi, lo, sum)
+		parts[i] = q
+		remainders[i] = r
+		remaining -= q
+	}
+	if remaining > 0 {
+		// The sum of the fractional parts is less than the number of parts, so
+		// remaining < len(weights).
+		order := make([]int, len(weights))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			switch {
+			case remainders[a] > remainders[b]:
+				return -1
+			case remainders[a] < remainders[b]:
+				return 1
+			default:
+				return 0
+			}
+		})
+		for _, i := range order[:remaining] {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+// BucketOf returns the bucket of item i when n items (numbered 0 to n-1) are
+// split into b consecutive buckets whose sizes differ by at most one. The first
+// n%b buckets contain one more item than the rest.
+//
+// See BucketRange for the inverse mapping.
+func BucketOf(i, n, b int) int {
+	if i < 0 || i >= n {
+		panic("item out of range")
+	}
+	q, r := bucketSizes(n, b)
+	// The first r buckets have q+1 items.
+	if big := r * (q + 1); i >= big {
+		return r + (i-big)/q
+	}
+	return i / (q + 1)
+}
+
+// BucketRange returns the range of items [start, end) in the given bucket when
+// n items are split into b buckets (see BucketOf). The range can be empty if
+// n < b.
+func BucketRange(bucket, n, b int) (start, end int) {
+	if bucket < 0 || bucket >= b {
+		panic("bucket out of range")
+	}
+	q, r := bucketSizes(n, b)
+	start = bucket*q + min(bucket, r)
+	end = start + q
+	if bucket < r {
+		end++
+	}
+	return start, end
+}
+
+// bucketSizes returns the quotient and remainder of n/b, after validating the
+// arguments.
+func bucketSizes(n, b int) (q, r int) {
+	if n < 0 || b <= 0 {
+		panic("invalid number of items or buckets")
+	}
+	return n / b, n % b
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crmath
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestProportionalSplit(t *testing.T) {
+	for _, tc := range []struct {
+		total    int64
+		weights  []int64
+		expected []int64
+	}{
+		{total: 0, weights: nil, expected: nil},
+		{total: 10, weights: []int64{1}, expected: []int64{10}},
+		{total: 10, weights: []int64{1, 1}, expected: []int64{5, 5}},
+		{total: 10, weights: []int64{1, 1, 1}, expected: []int64{4, 3, 3}},
+		{total: 10, weights: []int64{0, 0, 0}, expected: []int64{4, 3, 3}},
+		{total: 10, weights: []int64{1, 0, 2}, expected: []int64{3, 0, 7}},
+		{total: 100, weights: []int64{333, 333, 334}, expected: []int64{33, 33, 34}},
+		{total: -10, weights: []int64{1, 1, 1}, expected: []int64{-4, -3, -3}},
+		{total: 7, weights: []int64{math.MaxInt64, math.MaxInt64}, expected: []int64{4, 3}},
+		{
+			total:    math.MaxInt64,
+			weights:  []int64{math.MaxInt64, 1},
+			expected: []int64{math.MaxInt64 - 1, 1},
+		},
+		{
+			total:    math.MinInt64,
+			weights:  []int64{1, 1},
+			expected: []int64{math.MinInt64 / 2, math.MinInt64 / 2},
+		},
+	} {
+		t.Run(fmt.Sprint(tc.total, tc.weights), func(t *testing.T) {
+			require.Equal(t, ProportionalSplit(tc.total, tc.weights), tc.expected)
+		})
+	}
+}
+
+func TestProportionalSplitRand(t *testing.T) {
+	for n := 0; n < 10000; n++ {
+		total := rand.Int64N(1 << rand.UintN(63))
+		weights := make([]int64, rand.IntN(20)+1)
+		for i := range weights {
+			weights[i] = rand.Int64N(1 << rand.UintN(40))
+		}
+		parts := ProportionalSplit(total, weights)
+		require.Equal(t, len(parts), len(weights))
+		var sum, weightSum int64
+		for i := range parts {
+			sum += parts[i]
+			weightSum += weights[i]
+		}
+		// Sum invariant.
+		require.Equal(t, sum, total)
+		// Each part must be within 1 of its exact proportional share.
+		if weightSum > 0 {
+			for i := range parts {
+				exact := float64(total) * float64(weights[i]) / float64(weightSum)
+				require.LE(t, math.Abs(float64(parts[i])-exact), 1+exact*1e-9)
+			}
+		}
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	start, end := BucketRange(0, 10, 3)
+	require.Equal(t, [2]int{start, end}, [2]int{0, 4})
+	start, end = BucketRange(2, 10, 3)
+	require.Equal(t, [2]int{start, end}, [2]int{7, 10})
+	require.Equal(t, BucketOf(4, 10, 3), 1)
+
+	for n := 0; n <= 50; n++ {
+		for b := 1; b <= 20; b++ {
+			// The buckets must be contiguous, cover all items, differ in size by at
+			// most one, and agree with BucketOf.
+			next := 0
+			for bucket := 0; bucket < b; bucket++ {
+				start, end := BucketRange(bucket, n, b)
+				require.Equal(t, start, next)
+				if size := end - start; size != n/b && size != n/b+1 {
+					t.Fatalf("n=%d b=%d: bucket %d has size %d", n, b, bucket, size)
+				}
+				for i := start; i < end; i++ {
+					require.Equal(t, BucketOf(i, n, b), bucket)
+				}
+				next = end
+			}
+			require.Equal(t, next, n)
+		}
+	}
+}
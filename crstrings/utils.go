@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 // JoinStringers concatenates the string representations of the given
@@ -97,3 +98,113 @@ func Lines(s string) []string {
 	}
 	return strings.Split(s, "\n")
 }
+
+// Dedent removes the longest common leading whitespace from all the lines in
+// the string (like Python's textwrap.dedent). This is useful for multi-line
+// string literals which are indented to match the surrounding code.
+//
+// Tabs and spaces are not considered equivalent: lines indented with "  " and
+// "\t" have no common leading whitespace. Lines that consist solely of
+// whitespace are ignored when determining the common prefix and are normalized
+// to empty lines in the result.
+func Dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	margin := ""
+	first := true
+	for _, l := range lines {
+		trimmed := strings.TrimLeft(l, " \t")
+		if trimmed == "" {
+			continue
+		}
+		indent := l[:len(l)-len(trimmed)]
+		if first {
+			margin = indent
+			first = false
+			continue
+		}
+		i := 0
+		for i < len(margin) && i < len(indent) && margin[i] == indent[i] {
+			i++
+		}
+		margin = margin[:i]
+	}
+	for i, l := range lines {
+		if strings.TrimLeft(l, " \t") == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = l[len(margin):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AbbrevMiddle abbreviates a long string (like a hex ID or a UUID) by keeping
+// the first keepStart and the last keepEnd runes, joined by sep (or by "…" if
+// sep is empty). For example, AbbrevMiddle("0123456789abcdef", 4, 4, "")
+// returns "0123…cdef".
+//
+// The string is returned unchanged if abbreviating it would not make it shorter
+// (i.e. if it has at most keepStart+keepEnd+len(sep) runes).
+func AbbrevMiddle(s string, keepStart, keepEnd int, sep string) string {
+	if sep == "" {
+		sep = "…"
+	}
+	n := utf8.RuneCountInString(s)
+	if n <= keepStart+keepEnd+utf8.RuneCountInString(sep) {
+		return s
+	}
+	// Find the byte offsets of the kept prefix and suffix.
+	start, end := 0, len(s)
+	for i := 0; i < keepStart; i++ {
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	for i := 0; i < keepEnd; i++ {
+		_, size := utf8.DecodeLastRuneInString(s[:end])
+		end -= size
+	}
+	return s[:start] + sep + s[end:]
+}
+
+// CutLast slices s around the last instance of sep, returning the text before
+// and after sep. The found result reports whether sep appears in s. If sep does
+// not appear in s, CutLast returns s, "", false (like strings.Cut).
+//
+// For example, CutLast("pkg.Type.Method", ".") returns "pkg.Type", "Method",
+// true.
+func CutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// CutN slices s around the n-th (non-overlapping) instance of sep, counting from
+// 1. CutN(s, sep, 1) is equivalent to strings.Cut(s, sep). If sep appears fewer
+// than n times in s, CutN returns s, "", false.
+//
+// An empty sep matches at the start of s and after each rune.
+func CutN(s, sep string, n int) (before, after string, found bool) {
+	if n <= 0 {
+		panic("n must be positive")
+	}
+	i := 0
+	for {
+		j := strings.Index(s[i:], sep)
+		if j < 0 {
+			return s, "", false
+		}
+		i += j
+		if n--; n == 0 {
+			return s[:i], s[i+len(sep):], true
+		}
+		if sep != "" {
+			i += len(sep)
+		} else if i < len(s) {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		} else {
+			return s, "", false
+		}
+	}
+}
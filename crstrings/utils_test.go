@@ -76,3 +76,71 @@ func TestLines(t *testing.T) {
 	require.Equal(t, `[]`, fmt.Sprintf("%q", Lines("")))
 	require.Equal(t, `[]`, fmt.Sprintf("%q", Lines("\n")))
 }
+
+func TestDedent(t *testing.T) {
+	require.Equal(t, "", Dedent(""))
+	require.Equal(t, "a\nb", Dedent("a\nb"))
+	require.Equal(t, "a\n  b\nc\n", Dedent("  a\n    b\n  c\n"))
+	// Blank lines are ignored and normalized.
+	require.Equal(t, "\na\n\n  b\n", Dedent("\n    a\n  \n      b\n"))
+	// Tabs and spaces are not equivalent.
+	require.Equal(t, "  a\n\tb", Dedent("  a\n\tb"))
+	require.Equal(t, "a\n b", Dedent("\t a\n\t  b"))
+	require.Equal(t, "SELECT *\nFROM t\n  WHERE x", Dedent(`
+		SELECT *
+		FROM t
+		  WHERE x`)[1:])
+}
+
+func TestAbbrevMiddle(t *testing.T) {
+	require.Equal(t, AbbrevMiddle("0123456789abcdef", 4, 4, ""), "0123…cdef")
+	require.Equal(t, AbbrevMiddle("0123456789abcdef", 4, 4, "..."), "0123...cdef")
+	require.Equal(t, AbbrevMiddle("0123456789abcdef", 0, 3, ""), "…def")
+	require.Equal(t, AbbrevMiddle("0123456789abcdef", 3, 0, ""), "012…")
+	// Short strings are unchanged.
+	require.Equal(t, AbbrevMiddle("012345678", 4, 4, ""), "012345678")
+	require.Equal(t, AbbrevMiddle("0123456789", 4, 4, ".."), "0123456789")
+	require.Equal(t, AbbrevMiddle("01234567890", 4, 4, ".."), "0123..7890")
+	// Multi-byte runes are not split.
+	require.Equal(t, AbbrevMiddle("αβγδεζηθικλμ", 2, 3, ""), "αβ…κλμ")
+	require.Equal(t, AbbrevMiddle("αβγδεζ", 2, 3, ""), "αβγδεζ")
+}
+
+func TestCutLast(t *testing.T) {
+	check := func(s, sep, before, after string, found bool) {
+		t.Helper()
+		b, a, f := CutLast(s, sep)
+		require.Equal(t, [3]any{b, a, f}, [3]any{before, after, found})
+	}
+	check("pkg.Type.Method", ".", "pkg.Type", "Method", true)
+	check("a::b::c", "::", "a::b", "c", true)
+	check("abc", ".", "abc", "", false)
+	check("abc.", ".", "abc", "", true)
+	check("abc", "", "abc", "", true)
+	check("", "", "", "", true)
+}
+
+func TestCutN(t *testing.T) {
+	check := func(s, sep string, n int, before, after string, found bool) {
+		t.Helper()
+		b, a, f := CutN(s, sep, n)
+		require.Equal(t, [3]any{b, a, f}, [3]any{before, after, found})
+	}
+	check("a.b.c.d", ".", 1, "a", "b.c.d", true)
+	check("a.b.c.d", ".", 2, "a.b", "c.d", true)
+	check("a.b.c.d", ".", 3, "a.b.c", "d", true)
+	check("a.b.c.d", ".", 4, "a.b.c.d", "", false)
+	check("aaaa", "aa", 2, "aa", "", true)
+	check("aaaa", "aa", 3, "aaaa", "", false)
+	check("αβ", "", 1, "", "αβ", true)
+	check("αβ", "", 2, "α", "β", true)
+	check("αβ", "", 3, "αβ", "", true)
+	check("αβ", "", 4, "αβ", "", false)
+	// CutN(s, sep, 1) is equivalent to strings.Cut.
+	for _, s := range []string{"", "x", "a.b", ".a.", "abc"} {
+		for _, sep := range []string{"", ".", "a", "bc"} {
+			b, a, f := strings.Cut(s, sep)
+			check(s, sep, 1, b, a, f)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crsync
+
+import "sync/atomic"
+
+// AtomicMaxInt64 atomically sets *addr to v if v is greater than the current
+// value.
+func AtomicMaxInt64(addr *atomic.Int64, v int64) {
+	for {
+		old := addr.Load()
+		if v <= old || addr.CompareAndSwap(old, v) {
+			return
+		}
+	}
+}
+
+// AtomicMinInt64 atomically sets *addr to v if v is less than the current
+// value.
+func AtomicMinInt64(addr *atomic.Int64, v int64) {
+	for {
+		old := addr.Load()
+		if v >= old || addr.CompareAndSwap(old, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crsync
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestAtomicMinMax(t *testing.T) {
+	var x atomic.Int64
+	AtomicMaxInt64(&x, 5)
+	require.Equal(t, x.Load(), 5)
+	AtomicMaxInt64(&x, 3)
+	require.Equal(t, x.Load(), 5)
+	AtomicMinInt64(&x, 7)
+	require.Equal(t, x.Load(), 5)
+	AtomicMinInt64(&x, -2)
+	require.Equal(t, x.Load(), -2)
+
+	var maxVal, minVal atomic.Int64
+	minVal.Store(math.MaxInt64)
+	values := make([]int64, 10000)
+	for i := range values {
+		values[i] = rand.Int64() - math.MaxInt64/2
+	}
+	var wg sync.WaitGroup
+	const numGoroutines = 8
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := g; i < len(values); i += numGoroutines {
+				AtomicMaxInt64(&maxVal, values[i])
+				AtomicMinInt64(&minVal, values[i])
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, maxVal.Load(), slices.Max(values))
+	require.Equal(t, minVal.Load(), slices.Min(values))
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crsync
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// CountdownLatch allows goroutines to wait until a count reaches zero. Unlike
+// sync.WaitGroup, waiting respects context cancellation.
+//
+// A latch is single-use: once the count reaches zero, it cannot be increased
+// again.
+type CountdownLatch struct {
+	count atomic.Int64
+	// done is closed when count transitions to zero.
+	done chan struct{}
+}
+
+// NewCountdownLatch creates a latch with the given initial count, which must be
+// positive.
+func NewCountdownLatch(count int64) *CountdownLatch {
+	if count <= 0 {
+		panic("invalid count")
+	}
+	l := &CountdownLatch{done: make(chan struct{})}
+	l.count.Store(count)
+	return l
+}
+
+// Add adds delta (which can be negative) to the count. If the count reaches
+// zero, all Wait calls are unblocked.
+//
+// Add with a positive delta must only be called while the count is known to be
+// positive (e.g. by a goroutine that has not yet called Done).
+func (l *CountdownLatch) Add(delta int64) {
+	switch n := l.count.Add(delta); {
+	case n < 0:
+		panic("negative latch count")
+	case n == 0 && delta != 0:
+		// Only one caller can observe the transition to zero, so the channel is
+		// closed exactly once. Add(0) on a latch that already reached zero is not
+		// a transition.
+		close(l.done)
+	case n == delta && delta > 0:
+		panic("Add called on a latch that already reached zero")
+	}
+}
+
+// Done decrements the count by one.
+func (l *CountdownLatch) Done() {
+	l.Add(-1)
+}
+
+// Wait blocks until the count reaches zero or the context is canceled. In the
+// latter case, the context error is returned.
+func (l *CountdownLatch) Wait(ctx context.Context) error {
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Count returns the current count.
+func (l *CountdownLatch) Count() int64 {
+	return l.count.Load()
+}
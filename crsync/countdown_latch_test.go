@@ -0,0 +1,80 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crsync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestCountdownLatch(t *testing.T) {
+	l := NewCountdownLatch(1)
+	ch := make(chan error, 10)
+	for i := 0; i < 5; i++ {
+		go func() {
+			ch <- l.Wait(context.Background())
+		}()
+	}
+	require.NoRecv(t, ch)
+	l.Add(2)
+	l.Done()
+	l.Done()
+	require.Equal(t, l.Count(), 1)
+	require.NoRecv(t, ch)
+	l.Done()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, require.Recv(t, ch))
+	}
+	require.NoError(t, l.Wait(context.Background()))
+}
+
+func TestCountdownLatchCancel(t *testing.T) {
+	l := NewCountdownLatch(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, l.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestCountdownLatchConcurrent(t *testing.T) {
+	const n = 100
+	l := NewCountdownLatch(n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Done()
+		}()
+	}
+	require.NoError(t, l.Wait(context.Background()))
+	wg.Wait()
+	require.Equal(t, l.Count(), 0)
+}
+
+// TestCountdownLatchAddZero checks that Add(0) is a no-op, including after the
+// count reached zero.
+func TestCountdownLatchAddZero(t *testing.T) {
+	l := NewCountdownLatch(1)
+	l.Add(0)
+	require.Equal(t, l.Count(), 1)
+	l.Done()
+	l.Add(0)
+	require.Equal(t, l.Count(), 0)
+	require.NoError(t, l.Wait(context.Background()))
+}
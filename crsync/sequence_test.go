@@ -0,0 +1,61 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crsync
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestSequence(t *testing.T) {
+	var s Sequence
+	require.Equal(t, s.Current(), 0)
+	require.Equal(t, s.Next(), 1)
+	require.Equal(t, s.Next(), 2)
+	require.Equal(t, s.Current(), 2)
+
+	const numGoroutines = 8
+	const perGoroutine = 1000
+	var wg sync.WaitGroup
+	results := make([][]uint64, numGoroutines)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				v := s.Next()
+				if c := s.Current(); c < v {
+					t.Errorf("Current() %d < Next() %d", c, v)
+				}
+				results[i] = append(results[i], v)
+			}
+		}()
+	}
+	wg.Wait()
+	// All values must be distinct and increasing within each goroutine.
+	seen := make(map[uint64]bool)
+	for _, r := range results {
+		for j, v := range r {
+			require.False(t, seen[v])
+			seen[v] = true
+			if j > 0 {
+				require.GT(t, v, r[j-1])
+			}
+		}
+	}
+	require.Equal(t, s.Current(), 2+numGoroutines*perGoroutine)
+}
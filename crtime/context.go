@@ -0,0 +1,31 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crtime
+
+import "context"
+
+// ContextWithMonoDeadline returns a copy of the parent context which is
+// canceled when the monotonic clock reaches the given deadline (or when the
+// parent is canceled, whichever happens first). If the deadline has already
+// passed, the returned context is already done (with
+// context.DeadlineExceeded).
+//
+// Note that ctx.Deadline() reports a wall clock time derived from the current
+// time and the remaining duration.
+func ContextWithMonoDeadline(
+	parent context.Context, deadline Mono,
+) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, deadline.Sub(NowMono()))
+}
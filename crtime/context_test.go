@@ -0,0 +1,46 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestContextWithMonoDeadline(t *testing.T) {
+	start := NowMono()
+	ctx, cancel := ContextWithMonoDeadline(context.Background(), start+Mono(20*time.Millisecond))
+	defer cancel()
+	require.Equal(t, ctx.Err(), nil)
+	<-ctx.Done()
+	require.Equal(t, ctx.Err(), context.DeadlineExceeded)
+	require.GE(t, start.Elapsed(), 20*time.Millisecond)
+
+	// Deadline in the past.
+	ctx, cancel = ContextWithMonoDeadline(context.Background(), start)
+	defer cancel()
+	require.Equal(t, ctx.Err(), context.DeadlineExceeded)
+
+	// Parent cancellation.
+	parent, parentCancel := context.WithCancel(context.Background())
+	ctx, cancel = ContextWithMonoDeadline(parent, NowMono()+Mono(time.Hour))
+	defer cancel()
+	parentCancel()
+	<-ctx.Done()
+	require.Equal(t, ctx.Err(), context.Canceled)
+}
@@ -15,6 +15,7 @@
 package crtime
 
 import (
+	"math"
 	"time"
 
 	"github.com/cockroachdb/crlib/crsync"
@@ -39,11 +40,52 @@ func (m Mono) Sub(other Mono) time.Duration {
 	return time.Duration(m - other)
 }
 
+// SubClamped returns the duration that elapsed between two moments, or zero if
+// m is before other (e.g. if the readings were taken out of order). It is
+// useful for computing a remaining time, like the time left until a deadline.
+func (m Mono) SubClamped(other Mono) time.Duration {
+	return max(m.Sub(other), 0)
+}
+
+// Add returns the moment d after m (or before m, if d is negative).
+//
+// The result saturates instead of wrapping around if it is out of the
+// representable range. Note that the result can be negative; a negative Mono
+// value is a moment before the start of the process (like those returned by
+// MonoFromTime for old times).
+func (m Mono) Add(d time.Duration) Mono {
+	res := m + Mono(d)
+	switch {
+	case d > 0 && res < m:
+		return math.MaxInt64
+	case d < 0 && res > m:
+		return math.MinInt64
+	}
+	return res
+}
+
 // Elapsed returns the duration that elapsed since m.
 func (m Mono) Elapsed() time.Duration {
 	return time.Duration(NowMono() - m)
 }
 
+// Time runs f and returns how long it took, as measured by the monotonic
+// clock. Note that this is elapsed (wall) time, not CPU time.
+func Time(f func()) time.Duration {
+	start := NowMono()
+	f()
+	return start.Elapsed()
+}
+
+// TimeValue runs f and returns its result along with how long it took, as
+// measured by the monotonic clock. Note that this is elapsed (wall) time, not
+// CPU time.
+func TimeValue[T any](f func() T) (T, time.Duration) {
+	start := NowMono()
+	v := f()
+	return v, start.Elapsed()
+}
+
 // ToUTC returns the UTC time corresponding to the monotonic time.
 //
 // The time is derived from the current wall clock, adjusted by the difference
@@ -51,7 +93,7 @@ func (m Mono) Elapsed() time.Duration {
 // since the Mono value was obtained, the result does not reflect the wall clock
 // at that point in time.
 func (m Mono) ToUTC() time.Time {
-	now := time.Now()
+	now := wallNow()
 	adjustment := time.Duration(m) - now.Sub(startTime)
 	return now.UTC().Add(adjustment)
 }
@@ -62,6 +104,25 @@ func MonoFromTime(t time.Time) Mono {
 	return Mono(t.Sub(startTime))
 }
 
+// MarshalWall returns the wall clock time corresponding to the monotonic time,
+// in UTC. It is equivalent to ToUTC; see ToUTC for caveats about wall clock
+// changes.
+func (m Mono) MarshalWall() time.Time {
+	return m.ToUTC()
+}
+
+// ParseWallToMono converts a wall clock time to a Mono value. It is equivalent
+// to MonoFromTime.
+func ParseWallToMono(t time.Time) Mono {
+	return MonoFromTime(t)
+}
+
+// AppendFormat appends the UTC time corresponding to the monotonic time (see
+// ToUTC) to buf, in RFC 3339 format with nanosecond precision.
+func (m Mono) AppendFormat(buf []byte) []byte {
+	return m.ToUTC().AppendFormat(buf, time.RFC3339Nano)
+}
+
 // AtomicMono provides atomic access to a Mono value.
 type AtomicMono = crsync.TypedAtomicInt64[Mono]
 
@@ -69,3 +130,8 @@ type AtomicMono = crsync.TypedAtomicInt64[Mono]
 // time.Since(). This solution is suggested by the Go runtime code:
 // https://github.com/golang/go/blob/889abb17e125bb0f5d8de61bb80ef15fbe2a130d/src/runtime/time_nofake.go#L19
 var startTime = time.Now()
+
+// wallNow is used by ToUTC to read the current time. Tests can override it
+// (along with startTime) to make the conversion deterministic; NowMono does not
+// use it.
+var wallNow = time.Now
@@ -15,6 +15,7 @@
 package crtime
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -32,15 +33,71 @@ func TestMono(t *testing.T) {
 	require.LE(t, c, d)
 
 	t.Run("ToUTC", func(t *testing.T) {
-		const d = 50 * time.Millisecond
-		const tolerance = time.Millisecond
-
-		start := NowMono()
-		expected := time.Now().UnixNano()
-		time.Sleep(d)
-		actual := start.ToUTC().UnixNano()
-		if actual < expected-tolerance.Nanoseconds() || actual > expected+tolerance.Nanoseconds() {
-			t.Fatalf("actual - expected = %s", time.Duration(actual-expected))
-		}
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("EST", -5*3600))
+		setFakeClock(t, start, start.Add(time.Hour))
+		m := Mono(10 * time.Minute)
+		require.Equal(t, m.ToUTC(), start.Add(10*time.Minute).UTC())
+		require.Equal(t, m.ToUTC().Location(), time.UTC)
+		require.Equal(t, MonoFromTime(m.ToUTC()), m)
+
+		// The result does not depend on the current time.
+		setFakeClock(t, start, start.Add(24*time.Hour))
+		require.Equal(t, m.ToUTC(), start.Add(10*time.Minute).UTC())
+	})
+	t.Run("Wall", func(t *testing.T) {
+		m := NowMono()
+		require.Equal(t, m.MarshalWall().Location(), time.UTC)
+		// The conversion reads the clock, so allow for a small error.
+		diff := ParseWallToMono(m.MarshalWall()).Sub(m)
+		require.LE(t, diff.Abs(), time.Millisecond)
+
+		buf := m.AppendFormat([]byte("ts="))
+		ts, err := time.Parse(time.RFC3339Nano, string(buf[len("ts="):]))
+		require.NoError(t, err)
+		require.LE(t, ts.Sub(m.ToUTC()).Abs(), time.Millisecond)
+	})
+}
+
+func TestMonoArithmetic(t *testing.T) {
+	a := Mono(10 * time.Second)
+	b := Mono(15 * time.Second)
+	require.Equal(t, b.SubClamped(a), 5*time.Second)
+	// Out of order readings.
+	require.Equal(t, a.SubClamped(b), 0)
+	require.Equal(t, a.SubClamped(a), 0)
+
+	require.Equal(t, a.Add(5*time.Second), b)
+	require.Equal(t, b.Add(-5*time.Second), a)
+	// Moments before the start of the process are valid.
+	require.Equal(t, a.Add(-20*time.Second), Mono(-10*time.Second))
+	// The result saturates instead of wrapping around.
+	require.Equal(t, a.Add(math.MaxInt64), Mono(math.MaxInt64))
+	require.Equal(t, Mono(-time.Second).Add(math.MinInt64), Mono(math.MinInt64))
+	require.Equal(t, Mono(math.MaxInt64).Add(-time.Second), Mono(math.MaxInt64-int64(time.Second)))
+}
+
+func TestTime(t *testing.T) {
+	d := Time(func() {
+		time.Sleep(10 * time.Millisecond)
+	})
+	require.GE(t, d, 10*time.Millisecond)
+
+	v, d := TimeValue(func() int {
+		time.Sleep(10 * time.Millisecond)
+		return 5
+	})
+	require.Equal(t, v, 5)
+	require.GE(t, d, 10*time.Millisecond)
+}
+
+// setFakeClock sets the process start time and the current wall clock time
+// used by ToUTC, for the duration of the test. The given times should not have
+// a monotonic clock reading, so that all calculations use the wall clock.
+func setFakeClock(t *testing.T, start, now time.Time) {
+	origStart, origNow := startTime, wallNow
+	t.Cleanup(func() {
+		startTime, wallNow = origStart, origNow
 	})
+	startTime = start
+	wallNow = func() time.Time { return now }
 }
@@ -0,0 +1,81 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crtime
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket rate limiter which uses the monotonic clock.
+// Tokens are added to the bucket at a fixed rate, up to a maximum (the burst);
+// each allowed event consumes tokens. It is safe for concurrent use.
+type RateLimiter struct {
+	rate  float64 // tokens per nanosecond
+	burst float64
+
+	mu struct {
+		sync.Mutex
+		tokens float64
+		// last is the last time tokens was updated.
+		last Mono
+	}
+}
+
+// NewRateLimiter creates a rate limiter which allows events at the given rate
+// (per second) with bursts of up to burst events. The bucket starts out full.
+func NewRateLimiter(ratePerSecond float64, burst int) *RateLimiter {
+	if !(ratePerSecond > 0) || burst <= 0 {
+		panic("invalid rate limiter configuration")
+	}
+	r := &RateLimiter{
+		rate:  ratePerSecond / float64(time.Second),
+		burst: float64(burst),
+	}
+	r.mu.tokens = r.burst
+	r.mu.last = NowMono()
+	return r
+}
+
+// Allow is shorthand for AllowN(1).
+func (r *RateLimiter) Allow() bool {
+	return r.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now; if so, the corresponding
+// tokens are consumed. AllowN never waits. A request for more than the burst
+// is never allowed.
+//
+// AllowN panics if n is negative.
+func (r *RateLimiter) AllowN(n int) bool {
+	return r.allowNAt(NowMono(), n)
+}
+
+func (r *RateLimiter) allowNAt(now Mono, n int) bool {
+	if n < 0 {
+		panic("negative number of events")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if elapsed := now.Sub(r.mu.last); elapsed > 0 {
+		r.mu.tokens = min(r.burst, r.mu.tokens+float64(elapsed)*r.rate)
+		r.mu.last = now
+	}
+	if float64(n) > r.mu.tokens {
+		return false
+	}
+	r.mu.tokens -= float64(n)
+	return true
+}
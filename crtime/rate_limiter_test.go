@@ -0,0 +1,67 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package crtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	r := NewRateLimiter(10, 5)
+	now := r.mu.last
+	// The bucket starts out full.
+	for i := 0; i < 5; i++ {
+		require.True(t, r.allowNAt(now, 1))
+	}
+	require.False(t, r.allowNAt(now, 1))
+	// One token is added every 100ms.
+	now += Mono(50 * time.Millisecond)
+	require.False(t, r.allowNAt(now, 1))
+	now += Mono(50 * time.Millisecond)
+	require.True(t, r.allowNAt(now, 1))
+	require.False(t, r.allowNAt(now, 1))
+
+	// The bucket does not grow beyond the burst.
+	now += Mono(time.Hour)
+	require.False(t, r.allowNAt(now, 6))
+	require.True(t, r.allowNAt(now, 3))
+	require.True(t, r.allowNAt(now, 2))
+	require.False(t, r.allowNAt(now, 1))
+
+	// Time going backwards (e.g. from concurrent callers) is ignored.
+	require.False(t, r.allowNAt(now-Mono(time.Second), 1))
+	now += Mono(200 * time.Millisecond)
+	require.True(t, r.allowNAt(now, 2))
+
+	// A negative n panics and doesn't add tokens beyond the burst.
+	now += Mono(time.Hour)
+	func() {
+		defer func() {
+			require.NotEqual(t, recover(), nil)
+		}()
+		r.allowNAt(now, -5)
+	}()
+	require.True(t, r.allowNAt(now, 5))
+	require.False(t, r.allowNAt(now, 1))
+
+	r = NewRateLimiter(1000, 1)
+	require.True(t, r.Allow())
+	require.False(t, r.AllowN(2))
+	time.Sleep(5 * time.Millisecond)
+	require.True(t, r.Allow())
+}
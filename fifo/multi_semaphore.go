@@ -0,0 +1,123 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fifo
+
+import (
+	"context"
+	"slices"
+)
+
+// MultiSemaphore allows acquiring units from several semaphores at once (for
+// example, a memory budget and a disk budget).
+//
+// Semaphores are always acquired in a consistent global order (regardless of
+// the order in which they were passed to NewMultiSemaphore), so concurrent
+// AcquireAll calls on MultiSemaphores which share semaphores cannot deadlock.
+//
+// Note that while an AcquireAll call is waiting on one of the semaphores, it
+// holds the units it already obtained from semaphores earlier in the order.
+type MultiSemaphore struct {
+	sems []*Semaphore
+	// order contains indexes into sems, in the order in which the semaphores
+	// must be acquired.
+	order []int
+}
+
+// NewMultiSemaphore creates a MultiSemaphore for the given semaphores. The same
+// semaphore cannot be passed more than once.
+func NewMultiSemaphore(sems ...*Semaphore) *MultiSemaphore {
+	m := &MultiSemaphore{
+		sems:  sems,
+		order: make([]int, len(sems)),
+	}
+	for i := range m.order {
+		m.order[i] = i
+	}
+	slices.SortFunc(m.order, func(a, b int) int {
+		switch ia, ib := sems[a].id, sems[b].id; {
+		case ia < ib:
+			return -1
+		case ia > ib:
+			return 1
+		default:
+			panic("duplicate semaphore")
+		}
+	})
+	return m
+}
+
+// AcquireAll acquires amounts[i] units from the i-th semaphore, waiting if
+// necessary. Either all units are acquired or none are: if the context is
+// canceled while waiting, any units acquired so far are released and the
+// context error is returned.
+//
+// On success, the caller must later release the units (using ReleaseAll or by
+// releasing from each semaphore).
+func (m *MultiSemaphore) AcquireAll(ctx context.Context, amounts []int64) error {
+	m.checkAmounts(amounts)
+	for i, idx := range m.order {
+		if err := m.sems[idx].Acquire(ctx, amounts[idx]); err != nil {
+			m.releaseOrdered(amounts, i)
+			return err
+		}
+	}
+	return nil
+}
+
+// TryAcquireAll attempts to acquire amounts[i] units from the i-th semaphore
+// without waiting. On success, returns true and the caller must later release
+// the units; on failure, no units are acquired.
+func (m *MultiSemaphore) TryAcquireAll(amounts []int64) bool {
+	m.checkAmounts(amounts)
+	for i, idx := range m.order {
+		if !m.sems[idx].TryAcquire(amounts[idx]) {
+			m.releaseOrdered(amounts, i)
+			return false
+		}
+	}
+	return true
+}
+
+// ReleaseAll releases amounts[i] units back to the i-th semaphore.
+func (m *MultiSemaphore) ReleaseAll(amounts []int64) {
+	m.checkAmounts(amounts)
+	m.releaseOrdered(amounts, len(m.order))
+}
+
+// Stats returns the current state of each semaphore, in the order in which they
+// were passed to NewMultiSemaphore.
+func (m *MultiSemaphore) Stats() []SemaphoreStats {
+	res := make([]SemaphoreStats, len(m.sems))
+	for i, s := range m.sems {
+		res[i] = s.Stats()
+	}
+	return res
+}
+
+// releaseOrdered releases the units from the first n semaphores in the
+// acquisition order.
+func (m *MultiSemaphore) releaseOrdered(amounts []int64, n int) {
+	// Release in reverse order.
+	for i := n - 1; i >= 0; i-- {
+		idx := m.order[i]
+		m.sems[idx].Release(amounts[idx])
+	}
+}
+
+func (m *MultiSemaphore) checkAmounts(amounts []int64) {
+	if len(amounts) != len(m.sems) {
+		panic("number of amounts does not match number of semaphores")
+	}
+}
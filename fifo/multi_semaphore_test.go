@@ -0,0 +1,96 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fifo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestMultiSemaphore(t *testing.T) {
+	a := NewSemaphore(10)
+	b := NewSemaphore(5)
+	// Pass the semaphores in reverse creation order.
+	m := NewMultiSemaphore(b, a)
+
+	require.True(t, m.TryAcquireAll([]int64{3, 8}))
+	require.Equal(t, m.Stats()[0].Outstanding, 3)
+	require.Equal(t, m.Stats()[1].Outstanding, 8)
+
+	// Not enough in a: nothing should be acquired.
+	require.False(t, m.TryAcquireAll([]int64{1, 3}))
+	require.Equal(t, b.Stats().Outstanding, 3)
+
+	ch := make(chan error, 1)
+	go func() {
+		ch <- m.AcquireAll(context.Background(), []int64{2, 3})
+	}()
+	require.NoRecv(t, ch)
+	a.Release(8)
+	require.NoError(t, require.Recv(t, ch))
+	require.Equal(t, a.Stats().Outstanding, 3)
+	require.Equal(t, b.Stats().Outstanding, 5)
+
+	// Cancellation while waiting must release the partial acquisition.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ch <- m.AcquireAll(ctx, []int64{1, 1})
+	}()
+	require.NoRecv(t, ch)
+	cancel()
+	require.True(t, errors.Is(require.Recv(t, ch), context.Canceled))
+	require.Equal(t, a.Stats().Outstanding, 3)
+	require.Equal(t, b.Stats().Outstanding, 5)
+
+	m.ReleaseAll([]int64{5, 3})
+	require.Equal(t, a.Stats().Outstanding, 0)
+	require.Equal(t, b.Stats().Outstanding, 0)
+}
+
+// TestMultiSemaphoreNoDeadlock runs concurrent acquisitions through
+// MultiSemaphores which list the same semaphores in different orders.
+func TestMultiSemaphoreNoDeadlock(t *testing.T) {
+	a, b, c := NewSemaphore(3), NewSemaphore(3), NewSemaphore(3)
+	multis := []*MultiSemaphore{
+		NewMultiSemaphore(a, b, c),
+		NewMultiSemaphore(c, b, a),
+		NewMultiSemaphore(b, c, a),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := multis[rand.Intn(len(multis))]
+			for j := 0; j < 100; j++ {
+				amounts := []int64{rand.Int63n(3) + 1, rand.Int63n(3) + 1, rand.Int63n(3) + 1}
+				if err := m.AcquireAll(context.Background(), amounts); err != nil {
+					t.Error(err)
+					return
+				}
+				m.ReleaseAll(amounts)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, s := range []*Semaphore{a, b, c} {
+		require.Equal(t, s.Stats().Outstanding, 0)
+	}
+}
@@ -23,16 +23,22 @@ import (
 // Queue implements an allocation efficient FIFO queue. It is not safe for
 // concurrent access.
 //
+// Elements can also be removed from the back of the queue (PopBack), which
+// allows the queue to be used as a stack.
+//
 // Note that the queue provides pointer access to the internal storage (via
-// PeekFront and PushBack) so it must be used with care. These pointers must not
-// be used once the respective element is popped out of the queue.
+// PeekFront, PeekBack and PushBack) so it must be used with care. These
+// pointers must not be used once the respective element is popped out of the
+// queue.
 //
 // -- Implementation --
 //
-// The queue is implemented as a linked list of nodes, where each node is a
-// small ring buffer. The nodes are allocated using a sync.Pool (a single pool
+// The queue is implemented as a doubly linked list of nodes, where each node is
+// a small ring buffer. The nodes are allocated using a sync.Pool (a single pool
 // should be created for any given type and is used for all queues of that
-// type).
+// type). The list can contain empty nodes after the tail node, preallocated by
+// Grow; PopBack keeps at most one empty node after the tail (as a spare for
+// PushBack) and releases the rest.
 type Queue[T any] struct {
 	len        int
 	head, tail *queueNode[T]
@@ -64,14 +70,41 @@ func (q *Queue[T]) PushBack(t T) *T {
 		q.head = q.pool.get()
 		q.tail = q.head
 	} else if q.tail.IsFull() {
-		newTail := q.pool.get()
-		q.tail.next = newTail
-		q.tail = newTail
+		if q.tail.next == nil {
+			q.tail.next = q.pool.get()
+			q.tail.next.prev = q.tail
+		}
+		// Note that tail.next can be non-nil if Grow or PopBack was used.
+		q.tail = q.tail.next
 	}
 	q.len++
 	return q.tail.PushBack(t)
 }
 
+// Grow ensures that n more elements can be pushed to the queue without
+// allocating backing nodes. The preallocated nodes are used by PushBack and are
+// recycled normally as elements are popped.
+func (q *Queue[T]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	if q.head == nil {
+		q.head = q.pool.get()
+		q.tail = q.head
+	}
+	// Available space in the tail node.
+	avail := queueNodeSize - int(q.tail.len)
+	last := q.tail
+	for ; last.next != nil; last = last.next {
+		avail += queueNodeSize
+	}
+	for ; avail < n; avail += queueNodeSize {
+		last.next = q.pool.get()
+		last.next.prev = last
+		last = last.next
+	}
+}
+
 // PeekFront returns the current head of the queue, or nil if the queue is
 // empty.
 //
@@ -91,14 +124,64 @@ func (q *Queue[T]) PopFront() {
 	// If this is the only node, we don't want to release it; otherwise we would
 	// allocate/free a node every time we transition between the queue being empty
 	// and non-empty.
-	if q.head.len == 0 && q.head.next != nil {
+	if q.head.len == 0 && q.head != q.tail {
 		oldHead := q.head
 		q.head = oldHead.next
+		q.head.prev = nil
 		q.pool.put(oldHead)
 	}
 	q.len--
 }
 
+// PeekBack returns the current tail of the queue, or nil if the queue is empty.
+//
+// The result is only valid until the next call to PopBack.
+func (q *Queue[T]) PeekBack() *T {
+	if q.len == 0 {
+		return nil
+	}
+	return q.tail.PeekBack()
+}
+
+// PopBack removes the current tail of the queue.
+//
+// It is illegal to call PopBack on an empty queue.
+func (q *Queue[T]) PopBack() {
+	q.tail.PopBack()
+	if q.tail.len == 0 && q.tail != q.head {
+		// We keep the empty node after the new tail, for use by PushBack (to avoid
+		// allocating/freeing a node when we push and pop around a node boundary).
+		// Any other spare nodes are released.
+		spare := q.tail
+		q.tail = spare.prev
+		for n := spare.next; n != nil; {
+			next := n.next
+			q.pool.put(n)
+			n = next
+		}
+		spare.next = nil
+	}
+	q.len--
+}
+
+// At returns the i-th element of the queue, counting from the front (starting
+// at 0), or nil if i is out of range. Note that the indices of the elements
+// shift as elements are popped from the front.
+//
+// At takes O(i/queueNodeSize) time. The result is only valid until the element
+// is popped.
+func (q *Queue[T]) At(i int) *T {
+	if i < 0 || i >= q.len {
+		return nil
+	}
+	n := q.head
+	for i >= int(n.len) {
+		i -= int(n.len)
+		n = n.next
+	}
+	return n.At(i)
+}
+
 // QueueBackingPool is a sync.Pool that used to allocate internal nodes
 // for Queue[T].
 type QueueBackingPool[T any] struct {
@@ -131,9 +214,9 @@ func (qp *QueueBackingPool[T]) put(n *queueNode[T]) {
 const queueNodeSize = 8
 
 type queueNode[T any] struct {
-	buf       [queueNodeSize]T
-	head, len int32
-	next      *queueNode[T]
+	buf        [queueNodeSize]T
+	head, len  int32
+	prev, next *queueNode[T]
 }
 
 func (qn *queueNode[T]) IsFull() bool {
@@ -165,3 +248,23 @@ func (qn *queueNode[T]) PopFront() T {
 	qn.len--
 	return t
 }
+
+func (qn *queueNode[T]) At(i int) *T {
+	return &qn.buf[(qn.head+int32(i))%queueNodeSize]
+}
+
+func (qn *queueNode[T]) PeekBack() *T {
+	return &qn.buf[(qn.head+qn.len-1)%queueNodeSize]
+}
+
+func (qn *queueNode[T]) PopBack() T {
+	if invariants.Enabled && qn.len == 0 {
+		panic("cannot pop from empty queue")
+	}
+	i := (qn.head + qn.len - 1) % queueNodeSize
+	t := qn.buf[i]
+	var zero T
+	qn.buf[i] = zero
+	qn.len--
+	return t
+}
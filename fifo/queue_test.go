@@ -67,3 +67,138 @@ func TestQueueRand(t *testing.T) {
 		}
 	}
 }
+
+func TestQueueGrow(t *testing.T) {
+	numNodes := func(q *Queue[int]) int {
+		n := 0
+		for node := q.head; node != nil; node = node.next {
+			n++
+		}
+		return n
+	}
+	q := MakeQueue[int](&pool)
+	q.Grow(0)
+	require.Equal(t, numNodes(&q), 0)
+	q.Grow(20)
+	require.Equal(t, numNodes(&q), 3)
+	for i := 0; i < 20; i++ {
+		q.PushBack(i)
+	}
+	require.Equal(t, numNodes(&q), 3)
+	// The tail node has space for 4 more elements.
+	q.Grow(4)
+	require.Equal(t, numNodes(&q), 3)
+	q.Grow(5)
+	require.Equal(t, numNodes(&q), 4)
+	for i := 0; i < 20; i++ {
+		require.Equal(t, *q.PeekFront(), i)
+		q.PopFront()
+	}
+	require.Equal(t, numNodes(&q), 2)
+
+	// Randomly interleave Grow with pushes and pops.
+	l, r := 0, 0
+	for iteration := 0; iteration < 100; iteration++ {
+		q.Grow(rand.Intn(50))
+		for n := rand.Intn(100); n > 0; n-- {
+			r++
+			q.PushBack(r)
+			require.Equal(t, q.Len(), r-l)
+		}
+		for n := rand.Intn(q.Len() + 1); n > 0; n-- {
+			l++
+			require.Equal(t, *q.PeekFront(), l)
+			q.PopFront()
+			require.Equal(t, q.Len(), r-l)
+		}
+	}
+}
+
+// TestQueueDeque tests random operations at both ends of the queue, against a
+// slice.
+func TestQueueDeque(t *testing.T) {
+	q := MakeQueue[int](&pool)
+	var expected []int
+	next := 0
+	for iteration := 0; iteration < 10000; iteration++ {
+		switch op := rand.Intn(10); {
+		case op < 5:
+			next++
+			q.PushBack(next)
+			expected = append(expected, next)
+		case op < 7 && len(expected) > 0:
+			require.Equal(t, *q.PeekFront(), expected[0])
+			q.PopFront()
+			expected = expected[1:]
+		case op < 9 && len(expected) > 0:
+			require.Equal(t, *q.PeekBack(), expected[len(expected)-1])
+			q.PopBack()
+			expected = expected[:len(expected)-1]
+		case op == 9:
+			q.Grow(rand.Intn(20))
+		}
+		require.Equal(t, q.Len(), len(expected))
+		if len(expected) == 0 {
+			require.Equal(t, q.PeekFront(), nil)
+			require.Equal(t, q.PeekBack(), nil)
+		} else {
+			i := rand.Intn(len(expected))
+			require.Equal(t, *q.At(i), expected[i])
+		}
+	}
+}
+
+// TestQueuePopBackReleasesNodes checks that draining the queue with PopBack
+// does not retain the emptied nodes.
+func TestQueuePopBackReleasesNodes(t *testing.T) {
+	numNodes := func(q *Queue[int]) int {
+		n := 0
+		for node := q.head; node != nil; node = node.next {
+			n++
+		}
+		return n
+	}
+	q := MakeQueue[int](&pool)
+	for burst := 0; burst < 3; burst++ {
+		for i := 0; i < 8000; i++ {
+			q.PushBack(i)
+		}
+		require.Equal(t, numNodes(&q), 1000)
+		for q.Len() > 0 {
+			q.PopBack()
+			// Besides the nodes in use (the head node is always kept), there is at
+			// most one spare node.
+			require.LE(t, numNodes(&q), max(1, (q.Len()+queueNodeSize-1)/queueNodeSize)+1)
+		}
+		require.LE(t, numNodes(&q), 2)
+	}
+	// Spare nodes preallocated by Grow are released as well.
+	q.Grow(100)
+	for i := 0; i < 20; i++ {
+		q.PushBack(i)
+	}
+	for q.Len() > 0 {
+		q.PopBack()
+	}
+	require.LE(t, numNodes(&q), 2)
+}
+
+func TestQueueAt(t *testing.T) {
+	q := MakeQueue[int](&pool)
+	require.Equal(t, q.At(0), nil)
+	for i := 0; i < 30; i++ {
+		q.PushBack(i)
+	}
+	// Pop some elements so that the head node is partially filled.
+	for i := 0; i < 5; i++ {
+		q.PopFront()
+	}
+	for i := 0; i < 25; i++ {
+		require.Equal(t, *q.At(i), i+5)
+	}
+	require.Equal(t, q.At(-1), nil)
+	require.Equal(t, q.At(25), nil)
+	*q.At(10) = 100
+	q.PopFront()
+	require.Equal(t, *q.At(9), 100)
+}
@@ -16,20 +16,29 @@ package fifo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/cockroachdb/crlib/crtime"
 	"github.com/cockroachdb/crlib/internal/invariants"
 )
 
 // Semaphore implements a weighted, dynamically reconfigurable semaphore which
 // respects context cancellation.
 //
-// The semaphore implements a FIFO policy, where Acquire requests are satisfied
-// in order. This policy provides fairness and prevents starvation but is
-// susceptible to head-of-line blocking, where a large request that can't be
-// satisfied blocks many other small requests that could be.
+// By default, the semaphore implements a FIFO policy, where Acquire requests
+// are satisfied in order. This policy provides fairness and prevents starvation
+// but is susceptible to head-of-line blocking, where a large request that can't
+// be satisfied blocks many other small requests that could be. Other policies
+// can be selected via SemaphoreOptions.Policy (see SemaphorePolicy).
 type Semaphore struct {
+	opts SemaphoreOptions
+	// id is a unique identifier, used to order semaphores (see MultiSemaphore).
+	id uint64
+
 	mu struct {
 		sync.Mutex
 
@@ -48,15 +57,85 @@ type Semaphore struct {
 		// numHadToWait accumulates the total number of Acquire requests which had
 		// to wait because the semaphore was exhausted.
 		numHadToWait int64
+
+		// totalAcquired and totalReleased accumulate the total number of units
+		// acquired and released.
+		totalAcquired int64
+		totalReleased int64
+
+		// closed is set by Close.
+		closed bool
 	}
 }
 
 // NewSemaphore creates a new semaphore with the given capacity.
 func NewSemaphore(capacity int64) *Semaphore {
+	return NewSemaphoreWithOptions(capacity, SemaphoreOptions{})
+}
+
+// SemaphoreOptions contains optional settings for a Semaphore.
+type SemaphoreOptions struct {
+	// OnAcquire, if set, is called after every successful acquisition of n
+	// units. The waited duration is the time the request spent queued; it is
+	// zero if the request did not have to wait. It is called without holding
+	// any internal locks.
+	OnAcquire func(n int64, waited time.Duration)
+	// OnRelease, if set, is called after n units are released. It is called
+	// without holding any internal locks.
+	OnRelease func(n int64)
+	// Policy determines the order in which queued requests are granted. The
+	// default is SemaphoreFIFO.
+	Policy SemaphorePolicy
+	// StarvationThreshold, if positive, bounds the starvation of the oldest
+	// waiter under the SemaphoreLIFO and SemaphoreBarging policies. Once the
+	// oldest waiter has been queued for longer than this duration, the
+	// semaphore temporarily behaves as SemaphoreFIFO: new requests queue behind
+	// it and released capacity is reserved for it until it is granted.
+	//
+	// It has no effect with SemaphoreFIFO, which never starves waiters.
+	StarvationThreshold time.Duration
+}
+
+// SemaphorePolicy determines how a Semaphore orders requests that have to
+// wait.
+type SemaphorePolicy uint8
+
+const (
+	// SemaphoreFIFO grants queued requests in arrival order. A new request
+	// always queues behind existing waiters, even if it could be satisfied.
+	SemaphoreFIFO SemaphorePolicy = iota
+	// SemaphoreLIFO grants the most recently queued request first. This can
+	// improve tail latency for fresh requests under overload (older requests
+	// are likely to have timed out anyway), at the cost of potentially starving
+	// older requests.
+	SemaphoreLIFO
+	// SemaphoreBarging allows a new request to acquire immediately if there is
+	// enough available capacity, regardless of any queued waiters. Queued
+	// requests are still granted in FIFO order. This avoids some head-of-line
+	// blocking but can starve large requests.
+	SemaphoreBarging
+)
+
+func (p SemaphorePolicy) String() string {
+	switch p {
+	case SemaphoreFIFO:
+		return "fifo"
+	case SemaphoreLIFO:
+		return "lifo"
+	case SemaphoreBarging:
+		return "barging"
+	default:
+		return fmt.Sprintf("SemaphorePolicy(%d)", p)
+	}
+}
+
+// NewSemaphoreWithOptions creates a new semaphore with the given capacity and
+// options.
+func NewSemaphoreWithOptions(capacity int64, opts SemaphoreOptions) *Semaphore {
 	if capacity <= 0 {
 		panic("invalid capacity")
 	}
-	s := &Semaphore{}
+	s := &Semaphore{opts: opts, id: semaIDGen.Add(1)}
 	s.mu.capacity = capacity
 	s.mu.waiters = MakeQueue[semaWaiter](&semaQueuePool)
 	return s
@@ -64,20 +143,40 @@ func NewSemaphore(capacity int64) *Semaphore {
 
 var semaQueuePool = MakeQueueBackingPool[semaWaiter]()
 
+var semaIDGen atomic.Uint64
+
+// ErrClosed is returned by Acquire when the semaphore is closed.
+var ErrClosed = errors.New("semaphore closed")
+
 // TryAcquire attempts to acquire n units from the semaphore without waiting. On
 // success, returns true and the caller must later Release the units.
+//
+// TryAcquire fails if the semaphore is closed. Otherwise, TryAcquire(0) always
+// succeeds.
 func (s *Semaphore) TryAcquire(n int64) bool {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if s.numWaitersLocked() == 0 && s.canAcquireLocked(n) {
-		s.mu.outstanding += n
+	if n == 0 {
+		closed := s.mu.closed
+		s.mu.Unlock()
+		return !closed
+	}
+	if !s.mu.closed && s.canAcquireImmediatelyLocked(n) {
+		s.acquireLocked(n)
+		s.mu.Unlock()
+		if s.opts.OnAcquire != nil {
+			s.opts.OnAcquire(n, 0)
+		}
 		return true
 	}
-
+	s.mu.Unlock()
 	return false
 }
 
+func (s *Semaphore) acquireLocked(n int64) {
+	s.mu.outstanding += n
+	s.mu.totalAcquired += n
+}
+
 func (s *Semaphore) canAcquireLocked(n int64) bool {
 	// We allow a request larger than the capacity as long as there are no
 	// outstanding units.
@@ -87,27 +186,55 @@ func (s *Semaphore) canAcquireLocked(n int64) bool {
 // Acquire n units from the semaphore, waiting if necessary.
 //
 // If the context is canceled while we are waiting, returns the context error.
+// If the semaphore is (or becomes) closed, returns ErrClosed (even if n is 0).
 //
 // If n exceeds the current capacity, the request will be allowed when there are
 // no other acquisitions (similar to n being equal to the capacity).
 //
 // On success, the caller must later Release the units.
+//
+// Unless the semaphore is closed, Acquire(ctx, 0) succeeds immediately, even
+// if there are other waiters; it does not affect the state of the semaphore.
 func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
 	s.mu.Lock()
+	if s.mu.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	if n == 0 {
+		s.mu.Unlock()
+		return nil
+	}
 
 	// Fast path.
-	if s.numWaitersLocked() == 0 && s.canAcquireLocked(n) {
-		s.mu.outstanding += n
+	if s.canAcquireImmediatelyLocked(n) {
+		s.acquireLocked(n)
 		s.mu.Unlock()
+		if s.opts.OnAcquire != nil {
+			s.opts.OnAcquire(n, 0)
+		}
 		return nil
 	}
 
+	var start crtime.Mono
+	if s.opts.OnAcquire != nil || s.opts.StarvationThreshold > 0 {
+		start = crtime.NowMono()
+	}
 	c := chanSyncPool.Get().(chan error)
 	defer chanSyncPool.Put(c)
-	w := s.mu.waiters.PushBack(semaWaiter{n: n, c: c})
+	w := s.mu.waiters.PushBack(semaWaiter{n: n, c: c, start: start})
 	s.mu.numHadToWait++
 	s.mu.Unlock()
 
+	err := s.wait(ctx, w, c)
+	if err == nil && s.opts.OnAcquire != nil {
+		s.opts.OnAcquire(n, start.Elapsed())
+	}
+	return err
+}
+
+// wait blocks until the waiter is notified or the context is canceled.
+func (s *Semaphore) wait(ctx context.Context, w *semaWaiter, c chan error) error {
 	select {
 	case <-ctx.Done():
 		s.mu.Lock()
@@ -134,15 +261,53 @@ func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
 
 // Release n units back. These must be units that were acquired by a previous
 // Acquire call. It is legal to split up or coalesce units when releasing.
+//
+// Release(0) is a no-op.
 func (s *Semaphore) Release(n int64) {
+	if n == 0 {
+		return
+	}
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	s.mu.outstanding -= n
+	s.mu.totalReleased += n
 	if s.mu.outstanding < 0 {
+		s.mu.Unlock()
 		panic("releasing more than was acquired")
 	}
 	s.processWaitersLocked()
+	s.mu.Unlock()
+	if s.opts.OnRelease != nil {
+		s.opts.OnRelease(n)
+	}
+}
+
+// Close marks the semaphore as closed: any waiting Acquire calls return
+// ErrClosed, as do all future Acquire calls (including for zero units), and all
+// future TryAcquire calls fail. Release continues to work normally, allowing
+// outstanding units to be returned.
+//
+// Returns ErrClosed if the semaphore was already closed.
+func (s *Semaphore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.closed {
+		return ErrClosed
+	}
+	s.mu.closed = true
+	for ; s.mu.waiters.Len() > 0; s.mu.waiters.PopFront() {
+		if w := s.mu.waiters.PeekFront(); w.c != nil {
+			w.c <- ErrClosed
+		}
+	}
+	s.mu.numCanceled = 0
+	return nil
+}
+
+// Closed returns true if Close was called.
+func (s *Semaphore) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.closed
 }
 
 // UpdateCapacity changes the capacity of the semaphore. If the new capacity is
@@ -166,9 +331,11 @@ func (s *Semaphore) Stats() SemaphoreStats {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	return SemaphoreStats{
-		Capacity:     s.mu.capacity,
-		Outstanding:  s.mu.outstanding,
-		NumHadToWait: s.mu.numHadToWait,
+		Capacity:      s.mu.capacity,
+		Outstanding:   s.mu.outstanding,
+		NumHadToWait:  s.mu.numHadToWait,
+		TotalAcquired: s.mu.totalAcquired,
+		TotalReleased: s.mu.totalReleased,
 	}
 }
 
@@ -184,14 +351,21 @@ type SemaphoreStats struct {
 	// created) that had to wait because the semaphore was exhausted. Useful for
 	// cumulative metrics.
 	NumHadToWait int64
+	// TotalAcquired is the total number of units acquired since the semaphore
+	// was created. It can be used (by computing the difference between two
+	// samples) to determine the acquisition throughput.
+	TotalAcquired int64
+	// TotalReleased is the total number of units released since the semaphore
+	// was created.
+	TotalReleased int64
 
 	// TODO(radu): consider keeping track of the total amount of time the
 	// semaphore was exhausted (i.e. there were waiters queued).
 }
 
 func (ss SemaphoreStats) String() string {
-	return fmt.Sprintf("capacity: %d, outstanding: %d, num-had-to-wait: %d",
-		ss.Capacity, ss.Outstanding, ss.NumHadToWait)
+	return fmt.Sprintf("capacity: %d, outstanding: %d, num-had-to-wait: %d, total-acquired: %d, total-released: %d",
+		ss.Capacity, ss.Outstanding, ss.NumHadToWait, ss.TotalAcquired, ss.TotalReleased)
 }
 
 type semaWaiter struct {
@@ -200,6 +374,9 @@ type semaWaiter struct {
 	// c is the channel on which Acquire is blocked. If the request is canceled,
 	// it is set to nil.
 	c chan error
+	// start is the time when the waiter was queued; it is only set if needed
+	// (for OnAcquire or StarvationThreshold).
+	start crtime.Mono
 }
 
 // numWaitersLocked returns how many requests (that have not been canceled) are
@@ -208,11 +385,50 @@ func (s *Semaphore) numWaitersLocked() int {
 	return s.mu.waiters.Len() - s.mu.numCanceled
 }
 
-// processWaitersLocked processes and notifies as many waiters from the head of
-// the queue as possible.
+// canAcquireImmediatelyLocked returns true if a new request for n units can
+// be granted without queuing. Under SemaphoreLIFO, a new request would be the
+// first in line anyway, so only SemaphoreFIFO defers to existing waiters.
+func (s *Semaphore) canAcquireImmediatelyLocked(n int64) bool {
+	if s.numWaitersLocked() > 0 && (s.opts.Policy == SemaphoreFIFO || s.starvingLocked()) {
+		return false
+	}
+	return s.canAcquireLocked(n)
+}
+
+// starvingLocked returns true if the oldest waiter has been waiting for longer
+// than the starvation threshold. Canceled waiters at the front of the queue are
+// cleaned up in the process.
+func (s *Semaphore) starvingLocked() bool {
+	if s.opts.StarvationThreshold <= 0 {
+		return false
+	}
+	for s.mu.waiters.Len() > 0 {
+		w := s.mu.waiters.PeekFront()
+		if w.c != nil {
+			return w.start.Elapsed() > s.opts.StarvationThreshold
+		}
+		s.mu.waiters.PopFront()
+		s.mu.numCanceled--
+	}
+	return false
+}
+
+// processWaitersLocked processes and notifies as many waiters as possible, in
+// the order determined by the policy (from the head of the queue, or from the
+// tail for SemaphoreLIFO unless the oldest waiter is starving).
 func (s *Semaphore) processWaitersLocked() {
-	for ; s.mu.waiters.Len() > 0; s.mu.waiters.PopFront() {
-		switch w := s.mu.waiters.PeekFront(); {
+	for s.mu.waiters.Len() > 0 {
+		lifo := s.opts.Policy == SemaphoreLIFO && !s.starvingLocked()
+		if s.mu.waiters.Len() == 0 {
+			return
+		}
+		var w *semaWaiter
+		if lifo {
+			w = s.mu.waiters.PeekBack()
+		} else {
+			w = s.mu.waiters.PeekFront()
+		}
+		switch {
 		case w.c == nil:
 			// Request was canceled, we can just clean it up.
 			s.mu.numCanceled--
@@ -222,13 +438,18 @@ func (s *Semaphore) processWaitersLocked() {
 
 		case s.canAcquireLocked(w.n):
 			// Request can be fulfilled.
-			s.mu.outstanding += w.n
+			s.acquireLocked(w.n)
 			w.c <- nil
 
 		default:
-			// Head of the queue needs to wait some more.
+			// Next waiter needs to wait some more.
 			return
 		}
+		if lifo {
+			s.mu.waiters.PopBack()
+		} else {
+			s.mu.waiters.PopFront()
+		}
 	}
 }
 
@@ -31,7 +31,7 @@ func TestSemaphoreAPI(t *testing.T) {
 	s := NewSemaphore(10)
 	require.Equal(t, s.TryAcquire(5), true)
 	require.Equal(t, s.TryAcquire(10), false)
-	require.Equal(t, "capacity: 10, outstanding: 5, num-had-to-wait: 0", s.Stats().String())
+	require.Equal(t, "capacity: 10, outstanding: 5, num-had-to-wait: 0, total-acquired: 5, total-released: 0", s.Stats().String())
 
 	ch := make(chan struct{}, 10)
 	go func() {
@@ -275,3 +275,266 @@ func TestConcurrentUpdatesAndAcquisitions(t *testing.T) {
 	require.Equal(t, stats.Capacity, 100)
 	require.Equal(t, stats.Outstanding, 0)
 }
+
+func TestSemaphoreHooks(t *testing.T) {
+	var mu sync.Mutex
+	var acquired, released []int64
+	var waited []time.Duration
+	s := NewSemaphoreWithOptions(10, SemaphoreOptions{
+		OnAcquire: func(n int64, w time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			acquired = append(acquired, n)
+			waited = append(waited, w)
+		},
+		OnRelease: func(n int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			released = append(released, n)
+		},
+	})
+	require.True(t, s.TryAcquire(6))
+	require.NoError(t, s.Acquire(context.Background(), 2))
+	require.False(t, s.TryAcquire(3))
+
+	ch := make(chan error, 1)
+	go func() {
+		ch <- s.Acquire(context.Background(), 5)
+	}()
+	require.NoRecv(t, ch)
+	time.Sleep(10 * time.Millisecond)
+	s.Release(6)
+	require.NoError(t, require.Recv(t, ch))
+	s.Release(7)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []int64{6, 2, 5}, acquired)
+	require.Equal(t, []int64{6, 7}, released)
+	require.Equal(t, waited[0], 0)
+	require.Equal(t, waited[1], 0)
+	require.GE(t, waited[2], 10*time.Millisecond)
+}
+
+// TestSemaphoreZero checks that acquiring or releasing zero units is a no-op
+// which never blocks.
+func TestSemaphoreZero(t *testing.T) {
+	s := NewSemaphore(1)
+	require.True(t, s.TryAcquire(0))
+	require.NoError(t, s.Acquire(context.Background(), 1))
+	// Queue up a waiter.
+	ch := make(chan error, 1)
+	go func() {
+		ch <- s.Acquire(context.Background(), 1)
+	}()
+	require.NoRecv(t, ch)
+
+	require.True(t, s.TryAcquire(0))
+	require.NoError(t, s.Acquire(context.Background(), 0))
+	s.Release(0)
+	require.NoRecv(t, ch)
+	require.Equal(t, s.Stats().Outstanding, 1)
+
+	s.Release(1)
+	require.NoError(t, require.Recv(t, ch))
+	s.Release(1)
+	require.Equal(t, s.Stats().Outstanding, 0)
+	require.Equal(t, s.Stats().NumHadToWait, 1)
+}
+
+// TestSemaphoreTotals checks Stats().TotalAcquired and TotalReleased.
+func TestSemaphoreTotals(t *testing.T) {
+	s := NewSemaphore(10)
+	require.True(t, s.TryAcquire(3))
+	require.NoError(t, s.Acquire(context.Background(), 7))
+	ch := make(chan error, 1)
+	go func() {
+		ch <- s.Acquire(context.Background(), 5)
+	}()
+	require.NoRecv(t, ch)
+	s.Release(6)
+	require.NoError(t, require.Recv(t, ch))
+	stats := s.Stats()
+	require.Equal(t, stats.TotalAcquired, 15)
+	require.Equal(t, stats.TotalReleased, 6)
+	require.Equal(t, stats.TotalAcquired-stats.TotalReleased, stats.Outstanding)
+	s.Release(9)
+	stats = s.Stats()
+	require.Equal(t, stats.TotalAcquired, 15)
+	require.Equal(t, stats.TotalReleased, 15)
+}
+
+// TestSemaphorePolicy checks the order in which queued requests are granted
+// under each policy.
+func TestSemaphorePolicy(t *testing.T) {
+	// run queues three requests (numbered 1 to 3, in this order) for the entire
+	// capacity of an exhausted semaphore, then releases the units and returns
+	// the order in which the requests were granted. Each granted request
+	// releases its units, allowing the next one to be granted.
+	run := func(policy SemaphorePolicy) []int {
+		s := NewSemaphoreWithOptions(3, SemaphoreOptions{Policy: policy})
+		require.True(t, s.TryAcquire(3))
+		granted := make(chan int, 3)
+		for i := 1; i <= 3; i++ {
+			go func() {
+				if err := s.Acquire(context.Background(), 3); err != nil {
+					t.Error(err)
+				}
+				granted <- i
+				s.Release(3)
+			}()
+			// Wait for the request to be queued.
+			for s.Stats().NumHadToWait < int64(i) {
+				runtime.Gosched()
+			}
+		}
+		s.Release(3)
+		return require.RecvN(t, granted, 3)
+	}
+	require.Equal(t, run(SemaphoreFIFO), []int{1, 2, 3})
+	require.Equal(t, run(SemaphoreLIFO), []int{3, 2, 1})
+	require.Equal(t, run(SemaphoreBarging), []int{1, 2, 3})
+
+	// A new request can bypass queued waiters only under SemaphoreBarging.
+	for _, policy := range []SemaphorePolicy{SemaphoreFIFO, SemaphoreLIFO, SemaphoreBarging} {
+		s := NewSemaphoreWithOptions(10, SemaphoreOptions{Policy: policy})
+		require.True(t, s.TryAcquire(8))
+		ch := make(chan error, 1)
+		go func() {
+			ch <- s.Acquire(context.Background(), 5)
+		}()
+		for s.Stats().NumHadToWait < 1 {
+			runtime.Gosched()
+		}
+		require.Equal(t, s.TryAcquire(1), policy != SemaphoreFIFO)
+		s.Release(s.Stats().Outstanding)
+		require.NoError(t, require.Recv(t, ch))
+	}
+	require.Equal(t, SemaphoreLIFO.String(), "lifo")
+}
+
+func TestSemaphoreClose(t *testing.T) {
+	s := NewSemaphore(10)
+	require.False(t, s.Closed())
+	require.NoError(t, s.Acquire(context.Background(), 8))
+	ch := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			ch <- s.Acquire(context.Background(), 5)
+		}()
+	}
+	require.NoRecv(t, ch)
+
+	require.NoError(t, s.Close())
+	require.True(t, s.Closed())
+	require.Equal(t, require.Recv(t, ch), ErrClosed)
+	require.Equal(t, require.Recv(t, ch), ErrClosed)
+	require.Equal(t, s.Close(), ErrClosed)
+
+	require.Equal(t, s.Acquire(context.Background(), 1), ErrClosed)
+	require.False(t, s.TryAcquire(1))
+	// Acquisitions of zero units fail too.
+	require.Equal(t, s.Acquire(context.Background(), 0), ErrClosed)
+	require.False(t, s.TryAcquire(0))
+	// Outstanding units can still be released.
+	s.Release(8)
+	require.Equal(t, s.Stats().Outstanding, 0)
+	require.Equal(t, s.Acquire(context.Background(), 1), ErrClosed)
+}
+
+// TestSemaphoreStarvationThreshold checks that the oldest waiter stops being
+// bypassed once it has waited for longer than the threshold.
+func TestSemaphoreStarvationThreshold(t *testing.T) {
+	const threshold = 20 * time.Millisecond
+	waitQueued := func(s *Semaphore, n int64) {
+		for s.Stats().NumHadToWait < n {
+			runtime.Gosched()
+		}
+	}
+
+	t.Run("barging", func(t *testing.T) {
+		s := NewSemaphoreWithOptions(10, SemaphoreOptions{
+			Policy:              SemaphoreBarging,
+			StarvationThreshold: threshold,
+		})
+		require.True(t, s.TryAcquire(5))
+		ch := make(chan error, 1)
+		go func() {
+			ch <- s.Acquire(context.Background(), 10)
+		}()
+		waitQueued(s, 1)
+		// Before the threshold, small requests can bypass the waiter.
+		require.True(t, s.TryAcquire(1))
+		s.Release(1)
+		time.Sleep(2 * threshold)
+		require.False(t, s.TryAcquire(1))
+		s.Release(5)
+		require.NoError(t, require.Recv(t, ch))
+		s.Release(10)
+		require.True(t, s.TryAcquire(1))
+	})
+
+	t.Run("lifo", func(t *testing.T) {
+		s := NewSemaphoreWithOptions(1, SemaphoreOptions{
+			Policy:              SemaphoreLIFO,
+			StarvationThreshold: threshold,
+		})
+		require.True(t, s.TryAcquire(1))
+		granted := make(chan int, 2)
+		for i := 1; i <= 2; i++ {
+			go func() {
+				if err := s.Acquire(context.Background(), 1); err != nil {
+					t.Error(err)
+				}
+				granted <- i
+				s.Release(1)
+			}()
+			waitQueued(s, int64(i))
+			if i == 1 {
+				time.Sleep(2 * threshold)
+			}
+		}
+		// The first waiter is starving, so it is granted before the second.
+		s.Release(1)
+		require.Equal(t, require.RecvN(t, granted, 2), []int{1, 2})
+	})
+
+	// A stream of small requests cannot starve a large request indefinitely.
+	t.Run("adversarial", func(t *testing.T) {
+		s := NewSemaphoreWithOptions(10, SemaphoreOptions{
+			Policy:              SemaphoreBarging,
+			StarvationThreshold: threshold,
+		})
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					if s.TryAcquire(2) {
+						time.Sleep(time.Millisecond)
+						s.Release(2)
+					} else {
+						runtime.Gosched()
+					}
+				}
+			}()
+		}
+		defer wg.Wait()
+		defer close(stop)
+		// Let the workers saturate the semaphore.
+		time.Sleep(5 * time.Millisecond)
+		ch := make(chan error, 1)
+		go func() {
+			ch <- s.Acquire(context.Background(), 10)
+		}()
+		require.NoError(t, require.RecvWithin(t, ch, 10*time.Second))
+		s.Release(10)
+	})
+}
@@ -62,3 +62,56 @@ func NoRecvWithin[T any](tb TB, ch chan T, within time.Duration) {
 	case <-time.After(within):
 	}
 }
+
+// RecvN asserts that n values are received on the channel (each within 1
+// second) and returns them.
+func RecvN[T any](tb TB, ch chan T, n int) []T {
+	res := make([]T, 0, n)
+	for len(res) < n {
+		select {
+		case v := <-ch:
+			res = append(res, v)
+		case <-time.After(1 * time.Second):
+			tb.Helper()
+			tb.Fatalf("received only %d of %d values on channel", len(res), n)
+			panic("unreachable")
+		}
+	}
+	return res
+}
+
+// RecvAll receives values from the channel until no value is received within
+// the specified duration (or until the channel is closed), and returns all the
+// received values.
+func RecvAll[T any](ch chan T, idle time.Duration) []T {
+	var res []T
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return res
+			}
+			res = append(res, v)
+		case <-time.After(idle):
+			return res
+		}
+	}
+}
+
+// RecvEqual asserts that the values in expected are received on the channel,
+// in order (each within 1 second). Use NoRecv afterwards to also assert that no
+// further value arrives.
+func RecvEqual[T comparable](tb TB, ch chan T, expected []T) {
+	for i, exp := range expected {
+		select {
+		case v := <-ch:
+			if v != exp {
+				tb.Helper()
+				tb.Fatalf("value %d received on channel: %v, expected %v", i, v, exp)
+			}
+		case <-time.After(1 * time.Second):
+			tb.Helper()
+			tb.Fatalf("received only %d of %d values on channel", i, len(expected))
+		}
+	}
+}
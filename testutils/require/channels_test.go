@@ -0,0 +1,70 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestRecvN(t *testing.T) {
+	ch := make(chan int, 10)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	require.Equal(t, []int{0, 1, 2}, require.RecvN(t, ch, 3))
+	msg := expectFail(t, func(tb require.TB) {
+		require.RecvN(tb, ch, 3)
+	})
+	require.Equal(t, "received only 2 of 3 values on channel", msg)
+}
+
+func TestRecvAll(t *testing.T) {
+	ch := make(chan int, 10)
+	require.Equal(t, 0, len(require.RecvAll(ch, time.Millisecond)))
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			ch <- i
+		}
+	}()
+	require.Equal(t, []int{0, 1, 2, 3, 4}, require.RecvAll(ch, 50*time.Millisecond))
+
+	ch <- 1
+	close(ch)
+	require.Equal(t, []int{1}, require.RecvAll(ch, time.Hour))
+}
+
+func TestRecvEqual(t *testing.T) {
+	ch := make(chan string, 10)
+	ch <- "a"
+	ch <- "b"
+	expectPass(t, func(tb require.TB) {
+		require.RecvEqual(tb, ch, []string{"a", "b"})
+	})
+	ch <- "a"
+	ch <- "c"
+	msg := expectFail(t, func(tb require.TB) {
+		require.RecvEqual(tb, ch, []string{"a", "b"})
+	})
+	require.Equal(t, "value 1 received on channel: c, expected b", msg)
+	ch <- "a"
+	msg = expectFail(t, func(tb require.TB) {
+		require.RecvEqual(tb, ch, []string{"a", "b"})
+	})
+	require.Equal(t, "received only 1 of 2 values on channel", msg)
+}
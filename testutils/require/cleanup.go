@@ -0,0 +1,42 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+// AcquireCleanup calls acquire and asserts that it succeeded; release is
+// registered to be called on the result when the test (or subtest) and all its
+// subtests complete.
+//
+// Instead of:
+//
+//	f, err := os.Open(path)
+//	if err != nil {
+//	  t.Fatal(err)
+//	}
+//	t.Cleanup(func() { f.Close() })
+//
+// We can use:
+//
+//	f := require.AcquireCleanup(t, func() (*os.File, error) {
+//	  return os.Open(path)
+//	}, func(f *os.File) { f.Close() })
+func AcquireCleanup[T any](tb CleanupTB, acquire func() (T, error), release func(T)) T {
+	v, err := acquire()
+	if err != nil {
+		tb.Helper()
+		tb.Fatalf("acquire failed: %v", err)
+	}
+	tb.Cleanup(func() { release(v) })
+	return v
+}
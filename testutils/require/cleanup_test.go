@@ -0,0 +1,51 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestAcquireCleanup(t *testing.T) {
+	var released []int
+	t.Run("subtest", func(t *testing.T) {
+		v := require.AcquireCleanup(t, func() (int, error) {
+			return 5, nil
+		}, func(v int) {
+			released = append(released, v)
+		})
+		require.Equal(t, v, 5)
+		require.Equal(t, len(released), 0)
+	})
+	require.Equal(t, released, []int{5})
+
+	msg := expectFail(t, func(tb require.TB) {
+		require.AcquireCleanup(&fakeCleanupTB{TB: tb}, func() (int, error) {
+			return 0, errors.New("boom")
+		}, func(int) {
+			t.Fatal("release called")
+		})
+	})
+	require.Equal(t, msg, "acquire failed: boom")
+}
+
+type fakeCleanupTB struct {
+	require.TB
+}
+
+func (*fakeCleanupTB) Cleanup(func()) {}
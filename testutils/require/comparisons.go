@@ -46,6 +46,43 @@ func GE[T ordered](tb TB, a, b T) {
 	}
 }
 
+// LTFunc asserts that a < b, according to the given comparison function (which
+// returns a negative number if a < b, zero if a == b, and a positive number if
+// a > b, like bytes.Compare or cmp.Compare).
+func LTFunc[T any](tb TB, a, b T, cmp func(a, b T) int) {
+	if !(cmp(a, b) < 0) {
+		tb.Helper()
+		tb.Fatalf("expected %v < %v", a, b)
+	}
+}
+
+// LEFunc asserts that a <= b, according to the given comparison function (see
+// LTFunc).
+func LEFunc[T any](tb TB, a, b T, cmp func(a, b T) int) {
+	if !(cmp(a, b) <= 0) {
+		tb.Helper()
+		tb.Fatalf("expected %v <= %v", a, b)
+	}
+}
+
+// GTFunc asserts that a > b, according to the given comparison function (see
+// LTFunc).
+func GTFunc[T any](tb TB, a, b T, cmp func(a, b T) int) {
+	if !(cmp(a, b) > 0) {
+		tb.Helper()
+		tb.Fatalf("expected %v > %v", a, b)
+	}
+}
+
+// GEFunc asserts that a >= b, according to the given comparison function (see
+// LTFunc).
+func GEFunc[T any](tb TB, a, b T, cmp func(a, b T) int) {
+	if !(cmp(a, b) >= 0) {
+		tb.Helper()
+		tb.Fatalf("expected %v >= %v", a, b)
+	}
+}
+
 type ordered interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr | ~float32 | ~float64 | ~string
 }
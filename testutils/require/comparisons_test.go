@@ -0,0 +1,46 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestComparisonsFunc(t *testing.T) {
+	a, b := []byte("abc"), []byte("abd")
+	expectPass(t, func(tb require.TB) {
+		require.LTFunc(tb, a, b, bytes.Compare)
+		require.LEFunc(tb, a, b, bytes.Compare)
+		require.LEFunc(tb, a, a, bytes.Compare)
+		require.GTFunc(tb, b, a, bytes.Compare)
+		require.GEFunc(tb, b, a, bytes.Compare)
+		require.GEFunc(tb, b, b, bytes.Compare)
+	})
+	for _, fn := range []func(tb require.TB){
+		func(tb require.TB) { require.LTFunc(tb, a, a, bytes.Compare) },
+		func(tb require.TB) { require.LEFunc(tb, b, a, bytes.Compare) },
+		func(tb require.TB) { require.GTFunc(tb, a, a, bytes.Compare) },
+		func(tb require.TB) { require.GEFunc(tb, a, b, bytes.Compare) },
+	} {
+		expectFail(t, fn)
+	}
+	msg := expectFail(t, func(tb require.TB) {
+		require.LTFunc(tb, b, a, bytes.Compare)
+	})
+	require.Equal(t, msg, "expected [97 98 100] < [97 98 99]")
+}
@@ -30,21 +30,47 @@ Failed assertions result in a t.Fatal() call.
   - [require.True]
   - [require.False]
 
+# Types
+
+  - [require.Implements]
+  - [require.IsType]
+
 # Comparisons
 
-  - [require.LT]
-  - [require.LE]
-  - [require.GT]
-  - [require.GE]
+  - [require.LT], [require.LTFunc]
+  - [require.LE], [require.LEFunc]
+  - [require.GT], [require.GTFunc]
+  - [require.GE], [require.GEFunc]
+  - [require.InULP]
+
+# Strings
+
+  - [require.Regexp], [require.NotRegexp]
+  - [require.JSONEq]
+
+# Slices
+
+  - [require.Subset]
+
+# Maps
+
+  - [require.MapEqual]
+  - [require.MapContainsKey]
+  - [require.MapSubset]
 
 # Channels
 
   - [require.Recv], [require.RecvWithin]
+  - [require.RecvN], [require.RecvAll], [require.RecvEqual]
   - [require.NoRecv], [require.NoRecvWithin]
 
 # Errors
-  - [require.NoError]
+  - [require.NoError], [require.NoErrorAt]
   - [require.NoError1], [require.NoError2]
+  - [require.ReturnsError], [require.ErrorMatches]
+
+# Cleanup
+  - [require.AcquireCleanup]
 
 # Including info in error messages
   - [require.WithMsg], [require.WithMsgf]
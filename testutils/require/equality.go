@@ -15,12 +15,29 @@
 package require
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
+
+	"github.com/cockroachdb/crlib/crbytes"
 )
 
 // Equal asserts that a and b are deeply equal.
+//
+// Byte slices are compared using bytes.Equal (with nil and empty slices still
+// considered different, as with reflect.DeepEqual); on failure, the message
+// shows the first differing offset.
 func Equal[T any](tb TB, a, b T) {
+	if aBytes, ok := any(a).([]byte); ok {
+		// Note that if T is an interface type, b might not be a byte slice.
+		if bBytes, ok := any(b).([]byte); ok {
+			if msg := byteSlicesDiff(aBytes, bBytes); msg != "" {
+				tb.Helper()
+				tb.Fatal(msg)
+			}
+			return
+		}
+	}
 	if !reflect.DeepEqual(a, b) {
 		tb.Helper()
 		aStr := fmt.Sprint(a)
@@ -33,6 +50,42 @@ func Equal[T any](tb TB, a, b T) {
 	}
 }
 
+// byteSlicesDiff returns an empty string if the two slices are equal (in the
+// sense of reflect.DeepEqual), or a message describing the difference.
+func byteSlicesDiff(a, b []byte) string {
+	if bytes.Equal(a, b) {
+		switch {
+		case (a == nil) == (b == nil):
+			return ""
+		case a == nil:
+			return "expected equality: nil vs empty byte slice"
+		default:
+			return "expected equality: empty vs nil byte slice"
+		}
+	}
+	offset, _, _, _ := crbytes.FirstDiff(a, b)
+	return fmt.Sprintf(
+		"expected equality of byte slices (lengths %d and %d); first difference at offset %d:\n  a[%d:]: %s\n  b[%d:]: %s",
+		len(a), len(b), offset, offset, formatBytesAt(a, offset), offset, formatBytesAt(b, offset),
+	)
+}
+
+// formatBytesAt formats (in hex and as a quoted string) a few bytes of b
+// starting at the given offset.
+func formatBytesAt(b []byte, offset int) string {
+	const maxBytes = 16
+	b = b[offset:]
+	if len(b) == 0 {
+		return "<end>"
+	}
+	suffix := ""
+	if len(b) > maxBytes {
+		b = b[:maxBytes]
+		suffix = "..."
+	}
+	return fmt.Sprintf("% x%s %q%s", b, suffix, b, suffix)
+}
+
 // NotEqual asserts that a and b are deeply equal.
 func NotEqual[T any](tb TB, a, b T) {
 	if reflect.DeepEqual(a, b) {
@@ -0,0 +1,65 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestEqualBytes(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.Equal(tb, []byte("abc"), []byte("abc"))
+		require.Equal(tb, []byte(nil), nil)
+		require.Equal(tb, []byte{}, []byte{})
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.Equal(tb, []byte("hello world"), []byte("hello there"))
+	})
+	require.Equal(t, msg, "expected equality of byte slices (lengths 11 and 11); first difference at offset 6:\n"+
+		"  a[6:]: 77 6f 72 6c 64 \"world\"\n"+
+		"  b[6:]: 74 68 65 72 65 \"there\"")
+	msg = expectFail(t, func(tb require.TB) {
+		require.Equal(tb, []byte("ab"), []byte("ab\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f\x10"))
+	})
+	require.Equal(t, msg, "expected equality of byte slices (lengths 2 and 19); first difference at offset 2:\n"+
+		"  a[2:]: <end>\n"+
+		"  b[2:]: 00 01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f... \"\\x00\\x01\\x02\\x03\\x04\\x05\\x06\\a\\b\\t\\n\\v\\f\\r\\x0e\\x0f\"...")
+	msg = expectFail(t, func(tb require.TB) {
+		require.Equal(tb, nil, []byte{})
+	})
+	require.Equal(t, msg, "expected equality: nil vs empty byte slice")
+
+	// Other types still use the generic path.
+	msg = expectFail(t, func(tb require.TB) {
+		require.Equal(tb, []int{1, 2}, []int{1, 3})
+	})
+	require.Equal(t, msg, "expected [1 2] == [1 3]")
+
+	// If T is an interface type, only one of the values might be a byte slice.
+	var x any = []byte("x")
+	msg = expectFail(t, func(tb require.TB) {
+		require.Equal(tb, x, nil)
+	})
+	require.Equal(t, msg, "expected [120] == <nil>")
+	msg = expectFail(t, func(tb require.TB) {
+		require.Equal[any](tb, []byte("x"), "x")
+	})
+	require.Equal(t, msg, "expected [120] == x")
+	expectPass(t, func(tb require.TB) {
+		require.Equal[any](tb, []byte("x"), []byte("x"))
+	})
+}
@@ -14,7 +14,11 @@
 
 package require
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // NoError asserts that err is nil.
 func NoError(tb TB, err error) {
@@ -24,6 +28,85 @@ func NoError(tb TB, err error) {
 	}
 }
 
+// NoErrorAt asserts that err is nil. On failure, the message is prefixed with
+// the given key/value pairs, which is useful to identify the failing case in
+// table-driven tests.
+//
+// Example:
+//
+//	require.NoErrorAt(t, err, "case", tc.name, "idx", i)
+//
+// A failure message would look like:
+//
+//	case=foo idx=3: unexpected error: boom
+func NoErrorAt(tb TB, err error, keyvals ...any) {
+	if err != nil {
+		tb.Helper()
+		NoError(WithMsg(tb, formatKeyVals(keyvals)), err)
+	}
+}
+
+// ReturnsError calls fn and asserts that it returns a non-nil error (and does
+// not panic). The error is returned for further inspection.
+func ReturnsError(tb TB, fn func() error) error {
+	err, panicVal := callRecover(fn)
+	if panicVal != nil {
+		tb.Helper()
+		tb.Fatalf("expected error, got panic: %v", panicVal)
+	}
+	if err == nil {
+		tb.Helper()
+		tb.Fatalf("expected error, got nil")
+	}
+	return err
+}
+
+// ErrorMatches asserts that errors.Is(err, target) and that the error message
+// contains substr. If target is nil, the errors.Is check is skipped; if substr
+// is empty, the message check is skipped (but err must still be non-nil).
+func ErrorMatches(tb TB, err error, target error, substr string) {
+	if err == nil {
+		tb.Helper()
+		tb.Fatalf("expected error matching %v %q, got nil", target, substr)
+	}
+	var failures []string
+	if target != nil && !errors.Is(err, target) {
+		failures = append(failures, fmt.Sprintf("error is not %v", target))
+	}
+	if substr != "" && !strings.Contains(err.Error(), substr) {
+		failures = append(failures, fmt.Sprintf("error message does not contain %q", substr))
+	}
+	if len(failures) > 0 {
+		tb.Helper()
+		tb.Fatalf("unexpected error: %v\n  %s", err, strings.Join(failures, "\n  "))
+	}
+}
+
+// callRecover calls fn and recovers from any panic.
+func callRecover(fn func() error) (err error, panicVal any) {
+	defer func() {
+		panicVal = recover()
+	}()
+	return fn(), nil
+}
+
+// formatKeyVals formats key/value pairs as "k1=v1 k2=v2". A trailing key
+// without a value is printed on its own.
+func formatKeyVals(keyvals []any) string {
+	var buf strings.Builder
+	for i := 0; i < len(keyvals); i += 2 {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		if i+1 < len(keyvals) {
+			fmt.Fprintf(&buf, "%v=%v", keyvals[i], keyvals[i+1])
+		} else {
+			fmt.Fprint(&buf, keyvals[i])
+		}
+	}
+	return buf.String()
+}
+
 // NoError1 is passed an arbitrary value and an error and panics if the error is
 // not-nil, otherwise returns the value. It can be used to get the return value
 // of a fallible function that must succeed.
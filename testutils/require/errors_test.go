@@ -0,0 +1,76 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestNoErrorAt(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.NoErrorAt(tb, nil, "case", "foo")
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.NoErrorAt(tb, errors.New("boom"), "case", "foo", "idx", 3)
+	})
+	require.Equal(t, msg, "case=foo idx=3: unexpected error: boom")
+	msg = expectFail(t, func(tb require.TB) {
+		require.NoErrorAt(tb, errors.New("boom"), "case", "foo", "dangling")
+	})
+	require.Equal(t, msg, "case=foo dangling: unexpected error: boom")
+}
+
+func TestReturnsError(t *testing.T) {
+	boom := errors.New("boom")
+	expectPass(t, func(tb require.TB) {
+		err := require.ReturnsError(tb, func() error { return boom })
+		require.Equal(t, err, boom)
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.ReturnsError(tb, func() error { return nil })
+	})
+	require.Equal(t, msg, "expected error, got nil")
+	msg = expectFail(t, func(tb require.TB) {
+		require.ReturnsError(tb, func() error { panic("oops") })
+	})
+	require.Equal(t, msg, "expected error, got panic: oops")
+}
+
+func TestErrorMatches(t *testing.T) {
+	boom := errors.New("boom")
+	err := fmt.Errorf("while frobbing widget 7: %w", boom)
+	expectPass(t, func(tb require.TB) {
+		require.ErrorMatches(tb, err, boom, "widget 7")
+		require.ErrorMatches(tb, err, nil, "widget 7")
+		require.ErrorMatches(tb, err, boom, "")
+		require.ErrorMatches(tb, err, nil, "")
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.ErrorMatches(tb, nil, boom, "widget")
+	})
+	require.Equal(t, msg, `expected error matching boom "widget", got nil`)
+	msg = expectFail(t, func(tb require.TB) {
+		require.ErrorMatches(tb, err, boom, "gadget")
+	})
+	require.Equal(t, msg, "unexpected error: while frobbing widget 7: boom\n  error message does not contain \"gadget\"")
+	msg = expectFail(t, func(tb require.TB) {
+		require.ErrorMatches(tb, err, errors.New("other"), "gadget")
+	})
+	require.Equal(t, msg, "unexpected error: while frobbing widget 7: boom\n  error is not other\n  error message does not contain \"gadget\"")
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+import "math"
+
+// InULP asserts that a and b are within maxULP units in the last place of each
+// other, i.e. that there are at most maxULP-1 representable float64 values
+// between them. This is useful when a fixed absolute or relative tolerance is
+// not appropriate across magnitudes.
+//
+// Positive and negative zero are considered equal (zero ULPs apart), and values
+// on opposite sides of zero are compared through zero; for example, the
+// smallest positive and negative subnormals are two ULPs apart.
+//
+// NaN never satisfies the assertion (not even when both values are NaN); use
+// math.IsNaN to check for NaN. An infinity only satisfies the assertion when
+// compared to the same infinity.
+func InULP(tb TB, a, b float64, maxULP uint) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		tb.Helper()
+		tb.Fatalf("expected %v and %v to be within %d ULP, but NaN is not comparable", a, b, maxULP)
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		if a != b {
+			tb.Helper()
+			tb.Fatalf("expected %v and %v to be within %d ULP", a, b, maxULP)
+		}
+		return
+	}
+	if d := ulpDistance(a, b); d > uint64(maxULP) {
+		tb.Helper()
+		tb.Fatalf("expected %v and %v to be within %d ULP, but they are %d ULP apart", a, b, maxULP, d)
+	}
+}
+
+// ulpDistance returns the number of ULPs between two finite values.
+func ulpDistance(a, b float64) uint64 {
+	ia, ib := orderedBits(a), orderedBits(b)
+	if ia < ib {
+		ia, ib = ib, ia
+	}
+	// The difference fits in a uint64 (even if it overflows an int64).
+	return uint64(ia) - uint64(ib)
+}
+
+// orderedBits maps a float64 to an int64 such that the order of the integers
+// matches the order of the floats, and consecutive floats map to consecutive
+// integers. Both zeros map to 0.
+func orderedBits(f float64) int64 {
+	b := math.Float64bits(f)
+	if b>>63 != 0 {
+		return -int64(b &^ (1 << 63))
+	}
+	return int64(b)
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestInULP(t *testing.T) {
+	next := func(f float64) float64 { return math.Nextafter(f, math.Inf(1)) }
+	prev := func(f float64) float64 { return math.Nextafter(f, math.Inf(-1)) }
+	smallest := math.SmallestNonzeroFloat64
+	expectPass(t, func(tb require.TB) {
+		require.InULP(tb, 1, 1, 0)
+		require.InULP(tb, 1, next(1), 1)
+		require.InULP(tb, next(next(1)), 1, 2)
+		require.InULP(tb, prev(1), next(1), 2)
+		require.InULP(tb, 1e300, next(1e300), 1)
+		require.InULP(tb, -1, prev(-1), 1)
+		require.InULP(tb, 0, math.Copysign(0, -1), 0)
+		require.InULP(tb, smallest, -smallest, 2)
+		require.InULP(tb, math.MaxFloat64, -math.MaxFloat64, math.MaxUint)
+		require.InULP(tb, math.Inf(1), math.Inf(1), 0)
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.InULP(tb, 1, next(next(1)), 1)
+	})
+	require.Equal(t, msg, "expected 1 and 1.0000000000000004 to be within 1 ULP, but they are 2 ULP apart")
+	expectFail(t, func(tb require.TB) {
+		require.InULP(tb, smallest, -smallest, 1)
+	})
+	expectFail(t, func(tb require.TB) {
+		require.InULP(tb, math.MaxFloat64, math.Inf(1), 1)
+	})
+	expectFail(t, func(tb require.TB) {
+		require.InULP(tb, math.Inf(-1), math.Inf(1), math.MaxUint)
+	})
+	msg = expectFail(t, func(tb require.TB) {
+		require.InULP(tb, math.NaN(), math.NaN(), 10)
+	})
+	require.Equal(t, msg, "expected NaN and NaN to be within 10 ULP, but NaN is not comparable")
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// JSONEq asserts that the two strings contain equivalent JSON values, ignoring
+// whitespace and the order of object keys. Invalid JSON on either side fails
+// the test.
+func JSONEq(tb TB, expected, actual string) {
+	var e, a any
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		tb.Helper()
+		tb.Fatalf("expected value is not valid JSON: %v\n  %s", err, expected)
+	}
+	if err := json.Unmarshal([]byte(actual), &a); err != nil {
+		tb.Helper()
+		tb.Fatalf("actual value is not valid JSON: %v\n  %s", err, actual)
+	}
+	if !reflect.DeepEqual(e, a) {
+		tb.Helper()
+		// Re-encode both values so that keys are sorted and the formatting is
+		// consistent, which makes the difference easier to spot.
+		eStr, _ := json.MarshalIndent(e, "  ", "  ")
+		aStr, _ := json.MarshalIndent(a, "  ", "  ")
+		tb.Fatalf("expected JSON equality:\n  expected: %s\n  actual:   %s", eStr, aStr)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestJSONEq(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.JSONEq(tb, `{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`)
+		require.JSONEq(tb, `null`, ` null `)
+	})
+
+	msg := expectFail(t, func(tb require.TB) {
+		require.JSONEq(tb, `{"a": 1, "b": 2}`, `{"b": 2, "a": 3}`)
+	})
+	require.Equal(t, `expected JSON equality:
+  expected: {
+    "a": 1,
+    "b": 2
+  }
+  actual:   {
+    "a": 3,
+    "b": 2
+  }`, msg)
+
+	msg = expectFail(t, func(tb require.TB) {
+		require.JSONEq(tb, `{`, `{}`)
+	})
+	require.True(t, strings.HasPrefix(msg, "expected value is not valid JSON"))
+
+	msg = expectFail(t, func(tb require.TB) {
+		require.JSONEq(tb, `{}`, `{]`)
+	})
+	require.True(t, strings.HasPrefix(msg, "actual value is not valid JSON"))
+}
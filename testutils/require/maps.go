@@ -0,0 +1,104 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// MapEqual asserts that the two maps contain the same keys and that the values
+// for each key are deeply equal. On failure, the message lists the missing and
+// extra keys and the keys with differing values.
+func MapEqual[K comparable, V any](tb TB, got, want map[K]V) {
+	var missing, extra, differ []string
+	for k, w := range want {
+		g, ok := got[k]
+		if !ok {
+			missing = append(missing, fmt.Sprint(k))
+		} else if !reflect.DeepEqual(g, w) {
+			differ = append(differ, fmt.Sprintf("%v: got %v, want %v", k, g, w))
+		}
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			extra = append(extra, fmt.Sprint(k))
+		}
+	}
+	if len(missing)+len(extra)+len(differ) == 0 {
+		return
+	}
+	tb.Helper()
+	var buf strings.Builder
+	buf.WriteString("expected map equality:")
+	writeSortedList(&buf, "missing keys", missing)
+	writeSortedList(&buf, "extra keys", extra)
+	writeSortedList(&buf, "differing values", differ)
+	tb.Fatal(buf.String())
+}
+
+// MapContainsKey asserts that the map contains the given key.
+func MapContainsKey[K comparable, V any](tb TB, m map[K]V, key K) {
+	if _, ok := m[key]; !ok {
+		tb.Helper()
+		tb.Fatalf("expected map to contain key %v", key)
+	}
+}
+
+// MapSubset asserts that every key in subset is also present in superset, with
+// a deeply equal value.
+func MapSubset[K comparable, V any](tb TB, superset, subset map[K]V) {
+	var missing, differ []string
+	for k, v := range subset {
+		if sv, ok := superset[k]; !ok {
+			missing = append(missing, fmt.Sprint(k))
+		} else if !reflect.DeepEqual(sv, v) {
+			differ = append(differ, fmt.Sprintf("%v: got %v, want %v", k, sv, v))
+		}
+	}
+	if len(missing)+len(differ) == 0 {
+		return
+	}
+	tb.Helper()
+	var buf strings.Builder
+	buf.WriteString("expected map subset:")
+	writeSortedList(&buf, "missing keys", missing)
+	writeSortedList(&buf, "differing values", differ)
+	tb.Fatal(buf.String())
+}
+
+// writeSortedList writes a titled list of items to buf, sorting the items (for
+// a deterministic message, since map iteration order is random) and limiting
+// the number of items.
+func writeSortedList(buf *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	slices.Sort(items)
+	fmt.Fprintf(buf, "\n  %s:", title)
+	for i, s := range items {
+		if i == maxListedItems {
+			fmt.Fprintf(buf, "\n    ... and %d more", len(items)-i)
+			break
+		}
+		fmt.Fprintf(buf, "\n    %s", s)
+	}
+}
+
+// maxListedItems is the maximum number of items listed in a failure message;
+// it keeps the output readable for large collections.
+const maxListedItems = 10
@@ -0,0 +1,80 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestMapEqual(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.MapEqual(tb, map[string]int{}, nil)
+		require.MapEqual(tb, map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1})
+	})
+
+	msg := expectFail(t, func(tb require.TB) {
+		got := map[string]int{"a": 1, "b": 2, "d": 4}
+		want := map[string]int{"a": 1, "b": 3, "c": 3}
+		require.MapEqual(tb, got, want)
+	})
+	require.Equal(t, `expected map equality:
+  missing keys:
+    c
+  extra keys:
+    d
+  differing values:
+    b: got 2, want 3`, msg)
+
+	msg = expectFail(t, func(tb require.TB) {
+		want := make(map[int]int)
+		for i := 0; i < 100; i++ {
+			want[i] = i
+		}
+		require.MapEqual(tb, nil, want)
+	})
+	require.True(t, strings.HasSuffix(msg, "... and 90 more"))
+}
+
+func TestMapContainsKey(t *testing.T) {
+	m := map[int]string{1: "a"}
+	expectPass(t, func(tb require.TB) {
+		require.MapContainsKey(tb, m, 1)
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.MapContainsKey(tb, m, 2)
+	})
+	require.Equal(t, fmt.Sprintf("expected map to contain key %d", 2), msg)
+}
+
+func TestMapSubset(t *testing.T) {
+	superset := map[string]int{"a": 1, "b": 2, "c": 3}
+	expectPass(t, func(tb require.TB) {
+		require.MapSubset(tb, superset, nil)
+		require.MapSubset(tb, superset, map[string]int{"a": 1, "c": 3})
+		require.MapSubset(tb, superset, superset)
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.MapSubset(tb, superset, map[string]int{"a": 1, "b": 5, "d": 4})
+	})
+	require.Equal(t, `expected map subset:
+  missing keys:
+    d
+  differing values:
+    b: got 2, want 5`, msg)
+}
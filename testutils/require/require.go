@@ -27,6 +27,13 @@ type TB interface {
 	Logf(format string, args ...any)
 }
 
+// CleanupTB is a TB which also supports registering cleanup functions. It is
+// implemented by *testing.T and *testing.B.
+type CleanupTB interface {
+	TB
+	Cleanup(func())
+}
+
 // withMsg implements TB and prepends some information to all logs or error
 // messages.
 type withMsg struct {
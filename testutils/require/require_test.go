@@ -15,6 +15,7 @@
 package require_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/cockroachdb/crlib/testutils/require"
@@ -33,3 +34,66 @@ func TestWithMsg(t *testing.T) {
 	t3 := require.WithMsgf(t2, "bar")
 	t3.Log("hello3")
 }
+
+// fakeTB implements require.TB and records the failure message. Fatal calls
+// stop the execution of the assertion by panicking with fakeFatal{}.
+type fakeTB struct {
+	failed bool
+	msg    string
+}
+
+var _ require.TB = (*fakeTB)(nil)
+
+type fakeFatal struct{}
+
+func (f *fakeTB) Error(args ...any) { f.failed, f.msg = true, fmt.Sprint(args...) }
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failed, f.msg = true, fmt.Sprintf(format, args...)
+}
+func (f *fakeTB) Fatal(args ...any) {
+	f.Error(args...)
+	panic(fakeFatal{})
+}
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	panic(fakeFatal{})
+}
+func (f *fakeTB) Helper()                         {}
+func (f *fakeTB) Log(args ...any)                 {}
+func (f *fakeTB) Logf(format string, args ...any) {}
+
+// runFake runs fn against a fakeTB and returns whether it failed, along with
+// the failure message.
+func runFake(fn func(tb require.TB)) (failed bool, msg string) {
+	f := &fakeTB{}
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(fakeFatal); !ok {
+					panic(r)
+				}
+			}
+		}()
+		fn(f)
+	}()
+	return f.failed, f.msg
+}
+
+// expectPass fails the test if fn fails the fake TB.
+func expectPass(t *testing.T, fn func(tb require.TB)) {
+	t.Helper()
+	if failed, msg := runFake(fn); failed {
+		t.Fatalf("unexpected failure: %s", msg)
+	}
+}
+
+// expectFail fails the test if fn does not fail the fake TB, and returns the
+// failure message.
+func expectFail(t *testing.T, fn func(tb require.TB)) string {
+	t.Helper()
+	failed, msg := runFake(fn)
+	if !failed {
+		t.Fatalf("expected failure")
+	}
+	return msg
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Subset asserts that every element of subset is also an element of superset.
+// Multiplicity is not taken into account (i.e. the slices are treated as sets):
+// an element that appears multiple times in subset only needs to appear once in
+// superset.
+func Subset[T comparable](tb TB, superset, subset []T) {
+	set := make(map[T]struct{}, len(superset))
+	for _, v := range superset {
+		set[v] = struct{}{}
+	}
+	var missing []T
+	for _, v := range subset {
+		if _, ok := set[v]; !ok {
+			missing = append(missing, v)
+			// Only list each missing element once.
+			set[v] = struct{}{}
+		}
+	}
+	if len(missing) > 0 {
+		tb.Helper()
+		var buf strings.Builder
+		for i, v := range missing {
+			if i == maxListedItems {
+				fmt.Fprintf(&buf, " ... and %d more", len(missing)-i)
+				break
+			}
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			fmt.Fprint(&buf, v)
+		}
+		tb.Fatalf("expected subset; missing elements: %s", buf.String())
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestSubset(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.Subset(tb, []int{1, 2, 3}, nil)
+		require.Subset(tb, []int{1, 2, 3}, []int{3, 1})
+		// Multiplicity does not matter.
+		require.Subset(tb, []int{1, 2, 3}, []int{1, 1, 1})
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.Subset(tb, []int{1, 2, 3}, []int{5, 1, 4, 5})
+	})
+	require.Equal(t, "expected subset; missing elements: 5 4", msg)
+	msg = expectFail(t, func(tb require.TB) {
+		require.Subset(tb, nil, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	})
+	require.Equal(t, "expected subset; missing elements: 1 2 3 4 5 6 7 8 9 10 ... and 2 more", msg)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+import "regexp"
+
+// Regexp asserts that the string matches the regular expression. The pattern
+// can be a string or a precompiled *regexp.Regexp (which is preferable when
+// used in a loop); an invalid pattern string fails the test.
+func Regexp[P string | *regexp.Regexp](tb TB, pattern P, s string) {
+	if re := compileRegexp(tb, pattern); !re.MatchString(s) {
+		tb.Helper()
+		tb.Fatalf("expected %q to match regexp %q", s, re)
+	}
+}
+
+// NotRegexp asserts that the string does not match the regular expression. The
+// pattern can be a string or a precompiled *regexp.Regexp; an invalid pattern
+// string fails the test.
+func NotRegexp[P string | *regexp.Regexp](tb TB, pattern P, s string) {
+	if re := compileRegexp(tb, pattern); re.MatchString(s) {
+		tb.Helper()
+		tb.Fatalf("expected %q to not match regexp %q", s, re)
+	}
+}
+
+func compileRegexp[P string | *regexp.Regexp](tb TB, pattern P) *regexp.Regexp {
+	switch p := any(pattern).(type) {
+	case *regexp.Regexp:
+		return p
+	case string:
+		re, err := regexp.Compile(p)
+		if err != nil {
+			tb.Helper()
+			tb.Fatalf("invalid regexp %q: %v", p, err)
+		}
+		return re
+	default:
+		panic("unreachable")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+func TestRegexp(t *testing.T) {
+	re := regexp.MustCompile(`^\d+ ms$`)
+	expectPass(t, func(tb require.TB) {
+		require.Regexp(tb, `^\d+ ms$`, "12 ms")
+		require.Regexp(tb, re, "12 ms")
+		require.NotRegexp(tb, `^\d+ ms$`, "12 s")
+		require.NotRegexp(tb, re, "12 s")
+	})
+
+	msg := expectFail(t, func(tb require.TB) {
+		require.Regexp(tb, re, "12 s")
+	})
+	require.Equal(t, `expected "12 s" to match regexp "^\\d+ ms$"`, msg)
+
+	msg = expectFail(t, func(tb require.TB) {
+		require.NotRegexp(tb, `ms`, "12 ms")
+	})
+	require.Equal(t, `expected "12 ms" to not match regexp "ms"`, msg)
+
+	msg = expectFail(t, func(tb require.TB) {
+		require.Regexp(tb, `(`, "")
+	})
+	require.Regexp(t, `^invalid regexp "\(": `, msg)
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require
+
+import "reflect"
+
+// Implements asserts that the dynamic type of v implements the interface I.
+func Implements[I any](tb TB, v any) {
+	iface := reflect.TypeFor[I]()
+	if iface.Kind() != reflect.Interface {
+		tb.Helper()
+		tb.Fatalf("%s is not an interface type", iface)
+	}
+	if _, ok := v.(I); !ok {
+		tb.Helper()
+		tb.Fatalf("expected %T to implement %s", v, iface)
+	}
+}
+
+// IsType asserts that the dynamic type of v is exactly T.
+func IsType[T any](tb TB, v any) {
+	if t := reflect.TypeFor[T](); reflect.TypeOf(v) != t {
+		tb.Helper()
+		tb.Fatalf("expected type %s, got %T", t, v)
+	}
+}
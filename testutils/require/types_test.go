@@ -0,0 +1,62 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package require_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/cockroachdb/crlib/testutils/require"
+)
+
+type myErr struct{}
+
+func (*myErr) Error() string { return "my error" }
+
+func TestImplements(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.Implements[error](tb, &myErr{})
+		require.Implements[fmt.Stringer](tb, fs.ModeDir)
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.Implements[error](tb, myErr{})
+	})
+	require.Equal(t, msg, "expected require_test.myErr to implement error")
+	msg = expectFail(t, func(tb require.TB) {
+		require.Implements[error](tb, nil)
+	})
+	require.Equal(t, msg, "expected <nil> to implement error")
+	msg = expectFail(t, func(tb require.TB) {
+		require.Implements[int](tb, 1)
+	})
+	require.Equal(t, msg, "int is not an interface type")
+}
+
+func TestIsType(t *testing.T) {
+	expectPass(t, func(tb require.TB) {
+		require.IsType[*myErr](tb, &myErr{})
+		require.IsType[int64](tb, int64(1))
+	})
+	msg := expectFail(t, func(tb require.TB) {
+		require.IsType[*myErr](tb, errors.New("foo"))
+	})
+	require.Equal(t, msg, "expected type *require_test.myErr, got *errors.errorString")
+	msg = expectFail(t, func(tb require.TB) {
+		require.IsType[error](tb, &myErr{})
+	})
+	require.Equal(t, msg, "expected type error, got *require_test.myErr")
+}